@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+)
+
+// withAcceleratorAlternatives append Inferentia/Trainium alternatives of GPU instance types in results (same regions,
+// OS, price limit and sort order); alternatives are tagged with spot.TagNeuron caveat
+func withAcceleratorAlternatives(ctx context.Context, advices []spot.Advice, regions []string, instanceOS string, price float64,
+	sortBy int, sortDesc bool, opts ...spot.Option) ([]spot.Advice, error) {
+	families := make(map[string]bool)
+
+	for _, a := range advices {
+		for _, family := range spot.AcceleratorAlternatives(a.Instance) {
+			families[family] = true
+		}
+	}
+
+	if len(families) == 0 {
+		return advices, nil
+	}
+
+	names := make([]string, 0, len(families))
+	for family := range families {
+		names = append(names, family)
+	}
+
+	sort.Strings(names)
+
+	alternatives, err := spot.GetSpotSavingsContext(ctx, regions, "^("+strings.Join(names, "|")+`)\.`, instanceOS, 0, 0, price, sortBy, sortDesc, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get accelerator alternatives")
+	}
+
+	if len(alternatives) > 0 {
+		fmt.Fprintf(os.Stderr, "added %d %s alternatives to GPU instance types: models must be compiled with AWS Neuron SDK\n",
+			len(alternatives), strings.Join(names, "/"))
+	}
+
+	return append(advices, alternatives...), nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3" //nolint:gci
+)
+
+// batchQuery single named query of a batch; same semantics and defaults as command line flags
+type batchQuery struct {
+	Name       string   `json:"name" yaml:"name"`
+	Type       string   `json:"type" yaml:"type"`
+	OS         string   `json:"os" yaml:"os"`
+	Regions    []string `json:"region" yaml:"region"`
+	CPU        int      `json:"cpu" yaml:"cpu"`
+	Memory     int      `json:"memory" yaml:"memory"`
+	Price      float64  `json:"price" yaml:"price"`
+	Sort       string   `json:"sort" yaml:"sort"`
+	Order      string   `json:"order" yaml:"order"`
+	Compliance []string `json:"compliance" yaml:"compliance"`
+}
+
+func batchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "batch",
+		Usage: "run a JSON or YAML list of named queries and print combined JSON results keyed by query name",
+		Description: `Queries are read from FILE (YAML for .yaml/.yml files) or stdin when FILE is "-", for example:
+
+   [
+     {"name": "web", "type": "^m5\\.", "region": ["us-east-1"], "cpu": 2, "sort": "price"},
+     {"name": "gpu", "type": "^g5\\.", "region": ["eu-west-1", "us-west-2"], "price": 1.5}
+   ]
+
+   - name: web
+     type: ^m5\.
+     region: [us-east-1]
+     cpu: 2`,
+		ArgsUsage: "FILE|-",
+		Action:    batchCmd,
+	}
+}
+
+func batchCmd(c *cli.Context) error {
+	var (
+		bytes []byte
+		err   error
+	)
+
+	name := c.Args().First()
+
+	switch name {
+	case "":
+		return errors.New("queries file is required, use - for stdin")
+	case "-":
+		bytes, err = ioutil.ReadAll(os.Stdin)
+	default:
+		bytes, err = ioutil.ReadFile(name)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to read queries file")
+	}
+
+	queries, err := parseBatchQueries(bytes, name)
+	if err != nil {
+		return err
+	}
+
+	results := make(map[string][]spot.Advice, len(queries))
+
+	for i, q := range queries {
+		if q.Name == "" {
+			return errors.Errorf("query #%d has no name", i+1)
+		}
+
+		if _, ok := results[q.Name]; ok {
+			return errors.Errorf("duplicate query name %q", q.Name)
+		}
+
+		advices, err := runBatchQuery(c, &q)
+		if err != nil {
+			return errors.Wrapf(err, "query %q failed", q.Name)
+		}
+
+		results[q.Name] = advices
+	}
+
+	printAdvicesJSON(results)
+
+	return nil
+}
+
+// parseBatchQueries parse YAML (.yaml/.yml file) or JSON queries; stdin is JSON when it starts with "["
+func parseBatchQueries(bytes []byte, name string) ([]batchQuery, error) {
+	var (
+		queries []batchQuery
+		err     error
+	)
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == ".yaml" || ext == ".yml" || (name == "-" && !strings.HasPrefix(strings.TrimSpace(string(bytes)), "[")) {
+		err = yaml.Unmarshal(bytes, &queries)
+	} else {
+		err = json.Unmarshal(bytes, &queries)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse batch queries")
+	}
+
+	return queries, nil
+}
+
+func runBatchQuery(c *cli.Context, q *batchQuery) ([]spot.Advice, error) {
+	if len(q.Regions) == 0 {
+		q.Regions = []string{defaultRegion}
+	}
+
+	if q.OS == "" {
+		q.OS = "linux"
+	}
+
+	var opts []spot.Option
+	if len(q.Compliance) > 0 {
+		opts = append(opts, spot.WithCompliance(q.Compliance...))
+	}
+
+	advices, err := spot.GetSpotSavingsContext(c.Context, q.Regions, q.Type, q.OS, q.CPU, q.Memory, q.Price,
+		sortByName(q.Sort), strings.EqualFold(q.Order, "desc"), opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get spot savings")
+	}
+
+	// always encode empty results as JSON array
+	if advices == nil {
+		advices = []spot.Advice{}
+	}
+
+	return advices, nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+// workload resource requirements of a single workload in bulk inventory
+type workload struct {
+	Name   string `json:"name"`
+	CPU    int    `json:"vcpu"`
+	Memory int    `json:"memory"`
+	Region string `json:"region"`
+	OS     string `json:"os"`
+}
+
+// recommendation best spot instance for workload; Advice is nil if nothing matches
+type recommendation struct {
+	Workload workload     `json:"workload"`
+	Advice   *spot.Advice `json:"advice"`
+}
+
+func bulkCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "bulk",
+		Usage: "recommend the best spot instance for every workload in CSV or JSON inventory",
+		Description: `Inventory columns (CSV header) or JSON fields: name, vcpu, memory (GiB), region and os (default: linux).
+The best instance has at least requested vCPU and memory and the lowest interruption adjusted price (see --sort=adjusted-price);
+instance types without known spot price are skipped.
+
+   name,vcpu,memory,region,os
+   api,2,8,us-east-1,linux
+   reports,8,32,eu-west-1,windows`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "workload inventory file (.csv or .json), - for stdin (JSON if it starts with \"[\", CSV otherwise)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "EC2 instance type (can be RE2 regexp patten) to choose from",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: table|csv|json",
+				Value: "table",
+			},
+		},
+		Action: bulkCmd,
+	}
+}
+
+func bulkCmd(c *cli.Context) error {
+	workloads, err := readWorkloads(c.String("file"))
+	if err != nil {
+		return err
+	}
+
+	recommendations := make([]recommendation, 0, len(workloads))
+
+	for _, w := range workloads {
+		advices, err := spot.GetSpotSavingsContext(c.Context, []string{w.Region}, c.String("type"), w.OS, w.CPU, w.Memory, 0,
+			spot.SortByAdjustedPrice, false)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get spot savings for workload %q", w.Name)
+		}
+
+		// skip instance types without known spot price: they would always rank first
+		r := recommendation{Workload: w}
+
+		for i := range advices {
+			if advices[i].Price > 0 {
+				r.Advice = &advices[i]
+
+				break
+			}
+		}
+
+		recommendations = append(recommendations, r)
+	}
+
+	switch c.String("output") {
+	case "json":
+		printAdvicesJSON(recommendations)
+	case "csv":
+		printRecommendationsTable(recommendations, true, "")
+	default:
+		printRecommendationsTable(recommendations, false, c.String("theme"))
+	}
+
+	return nil
+}
+
+func readWorkloads(file string) ([]workload, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if file == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read workload inventory")
+	}
+
+	return parseWorkloads(data, file)
+}
+
+// parseWorkloads parse CSV or JSON (.json file or stdin starting with "[") workload inventory and apply defaults
+func parseWorkloads(data []byte, name string) ([]workload, error) {
+	var (
+		workloads []workload
+		err       error
+	)
+
+	if strings.EqualFold(filepath.Ext(name), ".json") || (name == "-" && bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))) {
+		err = json.Unmarshal(data, &workloads)
+	} else {
+		workloads, err = parseWorkloadsCSV(bytes.NewReader(data))
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse workload inventory")
+	}
+
+	for i := range workloads {
+		w := &workloads[i]
+		if w.Name == "" {
+			w.Name = fmt.Sprintf("workload-%d", i+1)
+		}
+
+		if w.Region == "" {
+			return nil, errors.Errorf("workload %q has no region", w.Name)
+		}
+
+		if w.OS == "" {
+			w.OS = "linux"
+		}
+	}
+
+	return workloads, nil
+}
+
+func parseWorkloadsCSV(input io.Reader) ([]workload, error) {
+	records, err := csv.NewReader(input).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CSV")
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("empty CSV")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	number := func(record []string, name string, line int) (int, error) {
+		value := field(record, name)
+		if value == "" {
+			return 0, nil
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, errors.Errorf("invalid %s %q on line %d", name, value, line)
+		}
+
+		return n, nil
+	}
+
+	workloads := make([]workload, 0, len(records)-1)
+
+	for i, record := range records[1:] {
+		w := workload{Name: field(record, "name"), Region: field(record, "region"), OS: field(record, "os")}
+
+		if w.CPU, err = number(record, "vcpu", i+2); err != nil { //nolint:gomnd
+			return nil, err
+		}
+
+		if w.Memory, err = number(record, "memory", i+2); err != nil { //nolint:gomnd
+			return nil, err
+		}
+
+		workloads = append(workloads, w)
+	}
+
+	return workloads, nil
+}
+
+func printRecommendationsTable(recommendations []recommendation, csv bool, theme string) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	header := table.Row{"Workload", regionColumn, instanceTypeColumn, vCPUColumn, memoryColumn, savingsColumn, interruptionColumn, priceColumn}
+	rows := make([]table.Row, 0, len(recommendations))
+
+	for _, r := range recommendations {
+		row := table.Row{r.Workload.Name, r.Workload.Region, "no match"}
+		if a := r.Advice; a != nil {
+			row = table.Row{r.Workload.Name, a.Region, a.Instance, a.Info.Cores, a.Info.RAM, a.Savings, a.Range.Label, a.Price}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if csv {
+		printCSV(os.Stdout, header, rows, csvFormat{})
+
+		return
+	}
+
+	t.AppendHeader(header)
+	t.AppendRows(rows)
+
+	t.SetColumnConfigs([]table.ColumnConfig{{
+		Name:        savingsColumn,
+		Transformer: text.NewNumberTransformer("%d%%"),
+	}})
+	t.SetStyle(tableStyle(theme))
+	t.Render()
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseWorkloadsCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []workload
+		wantErr string
+	}{
+		{
+			name:  "header order and case",
+			input: "OS, Region ,Memory,VCPU,Name\nwindows,eu-west-1,32,8,reports\n",
+			want:  []workload{{Name: "reports", CPU: 8, Memory: 32, Region: "eu-west-1", OS: "windows"}},
+		},
+		{
+			name:  "missing columns",
+			input: "name,region\napi,us-east-1\n",
+			want:  []workload{{Name: "api", Region: "us-east-1"}},
+		},
+		{
+			name:  "empty values",
+			input: "name,vcpu,memory,region\napi,,,us-east-1\n",
+			want:  []workload{{Name: "api", Region: "us-east-1"}},
+		},
+		{
+			name:    "non-numeric vcpu",
+			input:   "name,vcpu,memory,region\napi,2,8,us-east-1\nweb,two,8,us-east-1\n",
+			wantErr: `invalid vcpu "two" on line 3`,
+		},
+		{
+			name:    "non-numeric memory",
+			input:   "name,vcpu,memory,region\napi,2,8GiB,us-east-1\n",
+			wantErr: `invalid memory "8GiB" on line 2`,
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: "empty CSV",
+		},
+		{
+			name:  "header only",
+			input: "name,vcpu,memory,region\n",
+			want:  []workload{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWorkloadsCSV(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseWorkloadsCSV() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseWorkloadsCSV() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWorkloadsCSV() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseWorkloads(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		input   string
+		want    []workload
+		wantErr string
+	}{
+		{
+			name:  "csv defaults name and os",
+			file:  "workloads.csv",
+			input: "vcpu,memory,region\n2,8,us-east-1\n4,16,eu-west-1\n",
+			want: []workload{
+				{Name: "workload-1", CPU: 2, Memory: 8, Region: "us-east-1", OS: "linux"},
+				{Name: "workload-2", CPU: 4, Memory: 16, Region: "eu-west-1", OS: "linux"},
+			},
+		},
+		{
+			name:    "missing region",
+			file:    "workloads.csv",
+			input:   "name,vcpu,memory\napi,2,8\n",
+			wantErr: `workload "api" has no region`,
+		},
+		{
+			name:  "json file",
+			file:  "workloads.JSON",
+			input: `[{"name":"api","vcpu":2,"memory":8,"region":"us-east-1","os":"windows"},{"vcpu":1,"region":"eu-west-1"}]`,
+			want: []workload{
+				{Name: "api", CPU: 2, Memory: 8, Region: "us-east-1", OS: "windows"},
+				{Name: "workload-2", CPU: 1, Region: "eu-west-1", OS: "linux"},
+			},
+		},
+		{
+			name:    "invalid json",
+			file:    "workloads.json",
+			input:   `[{"vcpu":"two"}]`,
+			wantErr: "failed to parse workload inventory",
+		},
+		{
+			name:  "json on stdin",
+			file:  "-",
+			input: "\n  [{\"name\":\"api\",\"vcpu\":2,\"region\":\"us-east-1\"}]",
+			want:  []workload{{Name: "api", CPU: 2, Region: "us-east-1", OS: "linux"}},
+		},
+		{
+			name:  "csv on stdin",
+			file:  "-",
+			input: "name,vcpu,region\napi,2,us-east-1\n",
+			want:  []workload{{Name: "api", CPU: 2, Region: "us-east-1", OS: "linux"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWorkloads([]byte(tt.input), tt.file)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseWorkloads() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseWorkloads() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWorkloads() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readWorkloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spotinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "workloads.json")
+	if err = ioutil.WriteFile(file, []byte(`[{"name":"api","vcpu":2,"memory":8,"region":"us-east-1"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readWorkloads(file)
+	if err != nil {
+		t.Fatalf("readWorkloads() error = %v", err)
+	}
+
+	want := []workload{{Name: "api", CPU: 2, Memory: 8, Region: "us-east-1", OS: "linux"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readWorkloads() = %+v, want %+v", got, want)
+	}
+
+	if _, err = readWorkloads(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("readWorkloads() expected error for missing file")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+// adviseCommand spot advice query; same as running spotinfo with flags only
+func adviseCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "advise",
+		Usage:  "get spot instance advice (default command: flags without command run advise)",
+		Flags:  advisorFlags(),
+		Before: inheritFlags,
+		Action: mainCmd,
+	}
+}
+
+func regionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "regions",
+		Usage: "list AWS regions with spot data and their compliance tags",
+		Action: func(c *cli.Context) error {
+			regions, err := spot.Regions(c.Context)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			for _, region := range regions {
+				if tags := spot.RegionCompliance(region); len(tags) > 0 {
+					fmt.Printf("%s\t%s\n", region, strings.Join(tags, ","))
+				} else {
+					fmt.Println(region)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// generateCommand generate configuration snippets from advice query results
+func generateCommand() *cli.Command {
+	charts := []string{karpenterChart, clusterAutoscalerChart, sparkOperatorChart}
+	subcommands := make([]*cli.Command, 0, len(charts))
+
+	for _, chart := range charts {
+		chart := chart
+		subcommands = append(subcommands, &cli.Command{
+			Name:   chart,
+			Usage:  fmt.Sprintf("print %s Helm values snippet with resulting instance types", chart),
+			Flags:  advisorFlags(),
+			Before: inheritFlags,
+			Action: func(c *cli.Context) error {
+				if err := c.Set("helm-values", chart); err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				return mainCmd(c)
+			},
+		})
+	}
+
+	subcommands = append(subcommands, &cli.Command{
+		Name:   spotFleetGenerator,
+		Usage:  "print aws ec2 request-spot-fleet --cli-input-json document with resulting instance types",
+		Flags:  advisorFlags(),
+		Before: inheritFlags,
+		Action: func(c *cli.Context) error {
+			if err := c.Set(spotFleetGenerator, "true"); err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			return mainCmd(c)
+		},
+	})
+
+	return &cli.Command{
+		Name:        "generate",
+		Usage:       "generate configuration snippets with resulting instance types",
+		Subcommands: subcommands,
+	}
+}
+
+// inheritFlags copy advice query flags set before command name (spotinfo --type X advise) to command context, where
+// command flags of the same name would shadow them; a flag set both before and after command name is a usage error
+func inheritFlags(c *cli.Context) error {
+	local := localFlags(c)
+
+	for _, f := range advisorFlags() {
+		name := f.Names()[0]
+
+		for _, parent := range c.Lineage()[1:] {
+			// defaults from workspace config are not set on command line
+			if !localFlags(parent)[name] || workspaceFlags[name] {
+				continue
+			}
+
+			if local[name] {
+				return errors.Errorf("flag --%s is set both before and after command name %q", name, c.Command.Name)
+			}
+
+			if err := copyFlag(c, parent, f); err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// localFlags names of flags set in context itself, not in its parents
+func localFlags(c *cli.Context) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range c.LocalFlagNames() {
+		names[name] = true
+	}
+
+	return names
+}
+
+// copyFlag set flag of context to its value in parent context
+func copyFlag(c, parent *cli.Context, f cli.Flag) error {
+	name := f.Names()[0]
+
+	values := []string{fmt.Sprint(parent.Value(name))}
+	if _, ok := f.(*cli.StringSliceFlag); ok {
+		values = parent.StringSlice(name)
+	}
+
+	for _, value := range values {
+		if err := c.Set(name, value); err != nil {
+			return errors.Wrapf(err, "invalid value of flag --%s", name)
+		}
+	}
+
+	return nil
+}
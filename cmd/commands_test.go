@@ -0,0 +1,94 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func Test_inheritFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantType   string
+		wantRegion []string
+		wantErr    bool
+	}{
+		{
+			name:       "flags before command",
+			args:       []string{"--type", "^m5\\.xlarge$", "--region", "eu-west-1", "--region", "us-east-1", "advise"},
+			wantType:   "^m5\\.xlarge$",
+			wantRegion: []string{"eu-west-1", "us-east-1"},
+		},
+		{
+			name:       "flags after command",
+			args:       []string{"advise", "--type", "c5", "--region", "eu-west-1"},
+			wantType:   "c5",
+			wantRegion: []string{"eu-west-1"},
+		},
+		{
+			name:       "flags on both sides",
+			args:       []string{"--type", "c5", "advise", "--region", "eu-west-1"},
+			wantType:   "c5",
+			wantRegion: []string{"eu-west-1"},
+		},
+		{
+			name:       "generate subcommand",
+			args:       []string{"--type", "m5", "generate", "karpenter"},
+			wantType:   "m5",
+			wantRegion: []string{"us-east-1"},
+		},
+		{
+			name:    "same flag before and after command",
+			args:    []string{"--type", "m5", "advise", "--type", "c5"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotType   string
+				gotRegion []string
+			)
+
+			capture := func(c *cli.Context) error {
+				gotType, gotRegion = c.String("type"), c.StringSlice("region")
+
+				return nil
+			}
+
+			app := newApp()
+			for _, cmd := range app.Commands {
+				switch cmd.Name {
+				case "advise":
+					cmd.Action = capture
+				case "generate":
+					for _, sub := range cmd.Subcommands {
+						sub.Action = capture
+					}
+				}
+			}
+
+			args := append([]string{"spotinfo", "--no-update-check"}, tt.args...)
+
+			err := app.Run(args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if gotType != tt.wantType {
+				t.Errorf("--type = %q, want %q", gotType, tt.wantType)
+			}
+
+			if !reflect.DeepEqual(gotRegion, tt.wantRegion) {
+				t.Errorf("--region = %v, want %v", gotRegion, tt.wantRegion)
+			}
+		})
+	}
+}
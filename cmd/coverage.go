@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+func coverageCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "coverage",
+		Usage: "report regions missing from spot advisor or spot pricing data feed",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: text|json",
+				Value: "text",
+			},
+		},
+		Action: coverageCmd,
+	}
+}
+
+func coverageCmd(c *cli.Context) error {
+	coverage, err := spot.DataCoverage(c.Context)
+	if err != nil {
+		return errors.Wrap(err, "failed to get data feeds coverage")
+	}
+
+	if c.String("output") == "json" {
+		printAdvicesJSON(coverage)
+
+		return nil
+	}
+
+	list := func(regions []string) string {
+		if len(regions) == 0 {
+			return "none"
+		}
+
+		return strings.Join(regions, ", ")
+	}
+
+	fmt.Printf("covered by both data feeds (%d): %s\n", len(coverage.Covered), list(coverage.Covered))
+	fmt.Printf("no spot prices, results have zero price (%d): %s\n", len(coverage.AdvisorOnly), list(coverage.AdvisorOnly))
+	fmt.Printf("no spot advisor data, regions are skipped (%d): %s\n", len(coverage.PricingOnly), list(coverage.PricingOnly))
+
+	return nil
+}
+
+// warnCoverage warn about queried regions without spot prices
+func warnCoverage(ctx context.Context, regions []string) {
+	coverage, err := spot.DataCoverage(ctx)
+	if err != nil {
+		return
+	}
+
+	queried := make(map[string]bool, len(regions))
+	for _, region := range regions {
+		queried[region] = true
+	}
+
+	for _, region := range coverage.AdvisorOnly {
+		if queried[region] || queried["all"] {
+			log.Printf("warning: no spot prices for region %s in spot pricing data feed (see coverage command)", region)
+		}
+	}
+}
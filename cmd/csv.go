@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pkg/errors"
+)
+
+// csvFormat CSV output options; RFC 4180 quoting and CRLF line endings are always used
+type csvFormat struct {
+	delimiter rune // field delimiter, comma if not set
+	noHeader  bool // omit header line
+}
+
+// parseCSVDelimiter parse CSV field delimiter: single character, "tab" or "\t" for tab
+func parseCSVDelimiter(value string) (rune, error) {
+	if value == "tab" || value == `\t` {
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 || size != len(value) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, errors.Errorf("invalid CSV delimiter %q, must be a single character other than quote or line break", value)
+	}
+
+	return r, nil
+}
+
+// printCSV write rows (padded to header length) as RFC 4180 CSV: plain values, no table styles or colors
+func printCSV(out io.Writer, header table.Row, rows []table.Row, format csvFormat) {
+	w := csv.NewWriter(out)
+	w.UseCRLF = true
+
+	if format.delimiter != 0 {
+		w.Comma = format.delimiter
+	}
+
+	record := func(row table.Row) []string {
+		fields := make([]string, len(header))
+		for i := 0; i < len(row) && i < len(fields); i++ {
+			fields[i] = fmt.Sprint(row[i])
+		}
+
+		return fields
+	}
+
+	if !format.noHeader {
+		_ = w.Write(record(header))
+	}
+
+	for _, row := range rows {
+		_ = w.Write(record(row))
+	}
+
+	if w.Flush(); w.Error() != nil {
+		log.Printf("failed to write CSV: %v", w.Error())
+	}
+}
+
+// printTSV write header and rows as tab separated values; tabs and line breaks in values are replaced with spaces
+func printTSV(out io.Writer, header table.Row, rows []table.Row) {
+	escape := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+	line := func(row table.Row) string {
+		fields := make([]string, len(header))
+		for i := 0; i < len(row) && i < len(fields); i++ {
+			fields[i] = escape.Replace(fmt.Sprint(row[i]))
+		}
+
+		return strings.Join(fields, "\t")
+	}
+
+	fmt.Fprintln(out, line(header))
+
+	for _, row := range rows {
+		fmt.Fprintln(out, line(row))
+	}
+}
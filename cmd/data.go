@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+func dataCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "data",
+		Usage: "inspect spot advisor datasets",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "diff",
+				Usage:     "report instance types with changed interruption range or savings between two spot advisor datasets",
+				ArgsUsage: "OLD.json NEW.json",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "format output: text|json",
+						Value: "text",
+					},
+				},
+				Action: dataDiffCmd,
+			},
+		},
+	}
+}
+
+func dataDiffCmd(c *cli.Context) error {
+	if c.NArg() != 2 { //nolint:gomnd
+		return errors.New("old and new spot advisor data files are required")
+	}
+
+	older, err := ioutil.ReadFile(c.Args().Get(0))
+	if err != nil {
+		return errors.Wrap(err, "failed to read old spot advisor data")
+	}
+
+	newer, err := ioutil.ReadFile(c.Args().Get(1))
+	if err != nil {
+		return errors.Wrap(err, "failed to read new spot advisor data")
+	}
+
+	changes, err := spot.DiffAdvisorData(older, newer)
+	if err != nil {
+		return errors.Wrap(err, "failed to compare spot advisor data")
+	}
+
+	if c.String("output") == "json" {
+		// always encode empty results as JSON array
+		if changes == nil {
+			changes = []spot.DataChange{}
+		}
+
+		printAdvicesJSON(changes)
+
+		return nil
+	}
+
+	counts := make(map[string]int)
+
+	for _, ch := range changes {
+		counts[ch.Change]++
+		key := ch.Region + "/" + ch.OS + "/" + ch.Instance
+
+		switch ch.Change {
+		case spot.DataAdded:
+			fmt.Printf("+ %s: savings=%d%%, interruption='%s'\n", key, ch.NewSavings, ch.NewRange)
+		case spot.DataRemoved:
+			fmt.Printf("- %s\n", key)
+		default:
+			var diffs []string
+
+			if ch.OldRange != ch.NewRange {
+				diffs = append(diffs, fmt.Sprintf("interruption '%s' -> '%s'", ch.OldRange, ch.NewRange))
+			}
+
+			if ch.OldSavings != ch.NewSavings {
+				diffs = append(diffs, fmt.Sprintf("savings %d%% -> %d%%", ch.OldSavings, ch.NewSavings))
+			}
+
+			fmt.Printf("~ %s: %s\n", key, strings.Join(diffs, ", "))
+		}
+	}
+
+	fmt.Printf("%d added, %d removed, %d changed\n", counts[spot.DataAdded], counts[spot.DataRemoved], counts[spot.DataChanged])
+
+	return nil
+}
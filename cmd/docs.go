@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cpuguy83/go-md2man/v2/md2man"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// examples runnable command line examples by command name ("" for the default command)
+var examples = map[string][]string{
+	"": {
+		`spotinfo --type="^m5\." --region=eu-west-1 --sort=price`,
+		`spotinfo --type="m5a.xlarge" --output=json --region=us-east-1 --region=ap-south-1`,
+		`spotinfo --cpu=4 --memory=16 --price=0.2 --region=all --sort=adjusted-price`,
+	},
+	"advise": {
+		`spotinfo advise --type="^c6g\." --compliance=gdpr --region=all`,
+	},
+	"regions": {
+		`spotinfo regions`,
+	},
+	"generate": {
+		`spotinfo generate karpenter --type="^(m5|m5a)\.(x|2x)large$" --price=0.2`,
+		`spotinfo generate spot-fleet --type="^(m5|m5a)\.(x|2x)large$" --region=eu-west-1 --tags team=ml`,
+	},
+	"query": {
+		`spotinfo query save web --type="^m5\." --cpu=2 --sort=price`,
+		`spotinfo query run web --region=eu-west-1`,
+	},
+	"snapshots": {
+		`spotinfo --type="^m5\." --snapshot`,
+		`spotinfo snapshots diff 20210512T101500.123456Z latest`,
+	},
+	"batch": {
+		`spotinfo batch queries.json`,
+	},
+	"simulate": {
+		`spotinfo simulate --region=eu-west-1 --restart-cost=45m m5.large=4 m5.large=2,c5.xlarge=2`,
+	},
+	"insurance": {
+		`spotinfo insurance --checkpoint=30m --restart=10m --type="^m5\.(x|2x)large$" --region=eu-west-1`,
+	},
+	"reservation": {
+		`spotinfo reservation --duration=4h --type="^p3\.2xlarge$" --region=us-east-1 --savings-plan-discount=28`,
+	},
+	"stats": {
+		`spotinfo stats --type="^(m|c|r)[5-7]"`,
+	},
+	"heatmap": {
+		`spotinfo heatmap --type="^(c|m|r)6"`,
+		`spotinfo heatmap --format=html > heatmap.html`,
+	},
+	"bulk": {
+		`spotinfo bulk -f workloads.csv --output=csv`,
+	},
+	"analyze-tf": {
+		`spotinfo analyze-tf --region=eu-west-1 plan.json`,
+	},
+	"coverage": {
+		`spotinfo coverage --output=json`,
+	},
+	"data": {
+		`spotinfo data diff /tmp/spot-advisor-data.json public/spot/data/spot-advisor-data.json`,
+	},
+	"validate-fleet": {
+		`spotinfo validate-fleet spot-fleet-config.json`,
+		`spotinfo validate-fleet --region=eu-west-1 --output=json asg.json`,
+	},
+	"explain": {
+		`spotinfo explain m7gd.4xlarge x2iedn.metal`,
+	},
+	"docs": {
+		`spotinfo docs --format=man > spotinfo.8`,
+		`spotinfo docs --format=markdown > CLI.md`,
+	},
+}
+
+func docsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "docs",
+		Usage: "generate man page or Markdown CLI reference",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "documentation format: markdown|man",
+				Value: "markdown",
+			},
+		},
+		Action: docsCmd,
+	}
+}
+
+func docsCmd(c *cli.Context) error {
+	reference, err := c.App.ToMarkdown()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate CLI reference")
+	}
+
+	reference += examplesMarkdown()
+
+	switch format := c.String("format"); format {
+	case "markdown":
+		fmt.Print(reference)
+	case "man":
+		fmt.Print(string(md2man.Render([]byte(reference))))
+	default:
+		return errors.Errorf("unsupported documentation format %q, use markdown|man", format)
+	}
+
+	return nil
+}
+
+// examplesMarkdown EXAMPLES section of CLI reference
+func examplesMarkdown() string {
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var b strings.Builder
+
+	b.WriteString("\n# EXAMPLES\n")
+
+	for _, name := range names {
+		if name != "" {
+			fmt.Fprintf(&b, "\n## %s\n", name)
+		}
+
+		b.WriteString("\n```\n" + strings.Join(examples[name], "\n") + "\n```\n")
+	}
+
+	return b.String()
+}
+
+// examplesHelp examples section of command help
+func examplesHelp(name string) string {
+	if len(examples[name]) == 0 {
+		return ""
+	}
+
+	return "Examples:\n\n   " + strings.Join(examples[name], "\n   ")
+}
+
+// withExamples add examples to help of app and commands without own description
+func withExamples(app *cli.App) *cli.App {
+	app.Description = examplesHelp("")
+
+	for _, cmd := range app.Commands {
+		if cmd.Description == "" {
+			cmd.Description = examplesHelp(cmd.Name)
+		}
+	}
+
+	return app
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"regexp/syntax"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// errorOutput failure reported as JSON on stdout
+type errorOutput struct {
+	Error struct {
+		Code    string   `json:"code"`
+		Message string   `json:"message"`
+		Hints   []string `json:"hints,omitempty"`
+	} `json:"error"`
+}
+
+func newErrorOutput(err error) *errorOutput {
+	var (
+		out       errorOutput
+		syntaxErr *syntax.Error
+	)
+
+	out.Error.Message = err.Error()
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		out.Error.Code = "timeout"
+		out.Error.Hints = []string{"increase --timeout or --fetch-timeout", "narrow the query with --region or --type"}
+	case errors.Is(err, context.Canceled):
+		out.Error.Code = "canceled"
+	case errors.As(err, &syntaxErr):
+		out.Error.Code = "invalid_pattern"
+		out.Error.Hints = []string{"--type is a RE2 regular expression, e.g. \"^m5\\.\""}
+	default:
+		out.Error.Code = "error"
+	}
+
+	return &out
+}
+
+// jsonExitErrHandler report command failure, including Before errors, as JSON on stdout when JSON output is requested;
+// exit code stays non-zero
+func jsonExitErrHandler(c *cli.Context, err error) {
+	if err == nil || c.String("output") != "json" {
+		cli.HandleExitCoder(err)
+
+		return
+	}
+
+	printAdvicesJSON(newErrorOutput(err))
+	cli.HandleExitCoder(cli.Exit(err.Error(), 1))
+}
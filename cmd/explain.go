@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+func explainCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "explain",
+		Usage:     "explain instance type names: series, generation, attributes and size",
+		ArgsUsage: "INSTANCE_TYPE...",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: text|json",
+				Value: "text",
+			},
+		},
+		Action: explainCmd,
+	}
+}
+
+func explainCmd(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return errors.New("instance type is required, e.g. m7gd.4xlarge")
+	}
+
+	names := make([]*spot.InstanceName, 0, c.NArg())
+
+	for _, instance := range c.Args().Slice() {
+		name, err := spot.ParseInstanceName(instance)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		names = append(names, name)
+	}
+
+	if c.String("output") == "json" {
+		printAdvicesJSON(names)
+
+		return nil
+	}
+
+	for i, name := range names {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		printInstanceName(name)
+	}
+
+	return nil
+}
+
+func printInstanceName(name *spot.InstanceName) {
+	lifecycle := name.Lifecycle + " generation"
+	if name.LaunchYear != 0 {
+		lifecycle += fmt.Sprintf(", launched %d", name.LaunchYear)
+	}
+
+	fmt.Println(name.Instance)
+	fmt.Printf("  family:     %s (%s)\n", name.Family, lifecycle)
+	fmt.Printf("  series:     %s\n", formatNameParts([]spot.NamePart{name.Series}))
+	fmt.Printf("  generation: %d\n", name.Generation)
+
+	if len(name.Attributes) > 0 {
+		fmt.Printf("  attributes: %s\n", formatNameParts(name.Attributes))
+	}
+
+	if len(name.Options) > 0 {
+		fmt.Printf("  options:    %s\n", formatNameParts(name.Options))
+	}
+
+	size := name.Size
+	if name.Metal {
+		size += " (bare metal: no hypervisor, all host resources)"
+	}
+
+	fmt.Printf("  size:       %s\n", size)
+}
+
+// formatNameParts format name parts as "code - description" list
+func formatNameParts(parts []spot.NamePart) string {
+	items := make([]string, 0, len(parts))
+	for _, p := range parts {
+		items = append(items, p.Code+" - "+p.Description)
+	}
+
+	return strings.Join(items, "; ")
+}
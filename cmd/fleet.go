@@ -0,0 +1,126 @@
+package main
+
+import (
+	"log"
+	"sort"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+)
+
+const (
+	spotFleetGenerator = "spot-fleet"
+	// placeholders to replace before running aws ec2 request-spot-fleet
+	iamFleetRolePlaceholder   = "arn:aws:iam::ACCOUNT_ID:role/aws-ec2-spot-fleet-tagging-role"
+	launchTemplatePlaceholder = "LAUNCH_TEMPLATE_NAME"
+)
+
+// Spot Fleet allocation strategies of spotinfo allocation strategies; default is price-capacity-optimized
+var spotFleetAllocationStrategies = map[string]string{
+	"":                           "priceCapacityOptimized",
+	priceCapacityOptimized:       "priceCapacityOptimized",
+	capacityOptimizedPrioritized: "capacityOptimizedPrioritized",
+	lowestPrice:                  "lowestPrice",
+}
+
+// spotFleetInput aws ec2 request-spot-fleet --cli-input-json document
+type spotFleetInput struct {
+	SpotFleetRequestConfig spotFleetRequestConfig `json:"SpotFleetRequestConfig"` //nolint:tagliatelle
+}
+
+//nolint:tagliatelle
+type spotFleetRequestConfig struct {
+	AllocationStrategy    string                 `json:"AllocationStrategy"`
+	IamFleetRole          string                 `json:"IamFleetRole"`
+	TargetCapacity        int                    `json:"TargetCapacity"`
+	Type                  string                 `json:"Type"`
+	LaunchTemplateConfigs []launchTemplateConfig `json:"LaunchTemplateConfigs"`
+	TagSpecifications     []tagSpecification     `json:"TagSpecifications,omitempty"`
+}
+
+//nolint:tagliatelle
+type launchTemplateConfig struct {
+	LaunchTemplateSpecification struct {
+		LaunchTemplateName string `json:"LaunchTemplateName"`
+		Version            string `json:"Version"`
+	} `json:"LaunchTemplateSpecification"`
+	Overrides []launchTemplateOverride `json:"Overrides"`
+}
+
+//nolint:tagliatelle
+type launchTemplateOverride struct {
+	InstanceType string  `json:"InstanceType"`
+	Priority     float64 `json:"Priority,omitempty"`
+}
+
+//nolint:tagliatelle
+type tagSpecification struct {
+	ResourceType string     `json:"ResourceType"`
+	Tags         []fleetTag `json:"Tags"`
+}
+
+//nolint:tagliatelle
+type fleetTag struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+// printSpotFleetInput print aws ec2 request-spot-fleet --cli-input-json document with resulting instance types (in
+// result order); launch template and IAM fleet role are placeholders; tags are set on spot fleet request only: with
+// launch template configs, Spot Fleet takes instance tags from the launch template
+func printSpotFleetInput(advices []spot.Advice, strategy string, tags map[string]string) error {
+	if len(advices) == 0 {
+		return errors.New("no instance types to generate spot fleet request from")
+	}
+
+	for _, advice := range advices {
+		if advice.Region != advices[0].Region {
+			return errors.Errorf("spot fleet request is regional, results are in %s and %s: query a single region",
+				advices[0].Region, advice.Region)
+		}
+	}
+
+	allocation, ok := spotFleetAllocationStrategies[strategy]
+	if !ok {
+		return errors.Errorf("unsupported allocation strategy %q", strategy)
+	}
+
+	config := launchTemplateConfig{}
+	config.LaunchTemplateSpecification.LaunchTemplateName = launchTemplatePlaceholder
+	config.LaunchTemplateSpecification.Version = "$Latest"
+
+	for _, advice := range advices {
+		override := launchTemplateOverride{InstanceType: advice.Instance}
+		// lower number is higher priority
+		if strategy == capacityOptimizedPrioritized {
+			override.Priority = float64(len(config.Overrides) + 1)
+		}
+
+		config.Overrides = append(config.Overrides, override)
+	}
+
+	input := spotFleetInput{SpotFleetRequestConfig: spotFleetRequestConfig{
+		AllocationStrategy:    allocation,
+		IamFleetRole:          iamFleetRolePlaceholder,
+		TargetCapacity:        1,
+		Type:                  "maintain",
+		LaunchTemplateConfigs: []launchTemplateConfig{config},
+	}}
+
+	if len(tags) > 0 {
+		spec := tagSpecification{ResourceType: "spot-fleet-request"}
+		for key, value := range tags {
+			spec.Tags = append(spec.Tags, fleetTag{Key: key, Value: value})
+		}
+
+		sort.Slice(spec.Tags, func(i, j int) bool { return spec.Tags[i].Key < spec.Tags[j].Key })
+		input.SpotFleetRequestConfig.TagSpecifications = []tagSpecification{spec}
+
+		log.Printf("warning: --tags are set on spot fleet request only; tag instances in launch template %s", launchTemplatePlaceholder)
+	}
+
+	printAdvicesJSON(input)
+
+	return nil
+}
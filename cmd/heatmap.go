@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+// heatmap level: upper bound of mean interruption range midpoint (midpoints of mixed ranges average
+// between bands, so bounds lie between range midpoints), legend, glyphs and colors
+type heatLevel struct {
+	max    float64
+	legend string
+	glyph  string
+	ascii  string
+	color  text.Color
+	html   string
+}
+
+var heatLevels = []heatLevel{
+	{max: 5, legend: "<5%", glyph: "░░", ascii: "..", color: text.FgGreen, html: "#1a9850"},
+	{max: 11, legend: "5-10%", glyph: "▒▒", ascii: "--", color: text.FgHiGreen, html: "#91cf60"},
+	{max: 16, legend: "10-15%", glyph: "▓▓", ascii: "++", color: text.FgYellow, html: "#fee08b"},
+	{max: 30, legend: "15-20%", glyph: "██", ascii: "##", color: text.FgHiRed, html: "#fc8d59"},
+	{max: 100, legend: ">20%", glyph: "██", ascii: "@@", color: text.FgRed, html: "#d73027"},
+}
+
+func heatmapCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "heatmap",
+		Usage: "show interruption frequency heatmap of instance families by region",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "os",
+				Usage: "instance operating system (windows/linux)",
+				Value: "linux",
+			},
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "set one or more AWS regions",
+				Value: cli.NewStringSlice("all"),
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "EC2 instance type (can be RE2 regexp patten)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "heatmap format: terminal|html|json",
+				Value: "terminal",
+			},
+		},
+		Action: heatmapCmd,
+	}
+}
+
+func heatmapCmd(c *cli.Context) error {
+	advices, err := spot.GetSpotSavingsContext(c.Context, c.StringSlice("region"), c.String("type"), c.String("os"), 0, 0, 0, spot.SortByRegion, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get spot savings")
+	}
+
+	h := spot.NewHeatmap(advices)
+
+	switch format := c.String("format"); format {
+	case "terminal":
+		setColors(c.Bool("no-color"))
+		printHeatmap(h, c.String("theme") == themeASCII || !isTerminal(os.Stdout))
+	case "html":
+		printHeatmapHTML(h)
+	case "json":
+		printAdvicesJSON(h)
+	default:
+		return errors.Errorf("unsupported heatmap format %q, use terminal|html|json", format)
+	}
+
+	return nil
+}
+
+func heatLevelOf(value float64) *heatLevel {
+	for i := range heatLevels {
+		if value <= heatLevels[i].max {
+			return &heatLevels[i]
+		}
+	}
+
+	return &heatLevels[len(heatLevels)-1]
+}
+
+// regionCode short region code, e.g. "use1" for "us-east-1", "apne1" for "ap-northeast-1"
+func regionCode(region string) string {
+	directions := map[string]string{
+		"north": "n", "south": "s", "east": "e", "west": "w", "central": "c",
+		"northeast": "ne", "northwest": "nw", "southeast": "se", "southwest": "sw", "gov": "g",
+	}
+
+	parts := strings.Split(region, "-")
+	for i := 1; i < len(parts); i++ {
+		if code, ok := directions[parts[i]]; ok {
+			parts[i] = code
+		}
+	}
+
+	return strings.Join(parts, "")
+}
+
+func printHeatmap(h *spot.Heatmap, ascii bool) {
+	const familyWidth = 10
+
+	width := 0
+
+	codes := make([]string, len(h.Regions))
+	for i, region := range h.Regions {
+		codes[i] = regionCode(region)
+		if len(codes[i]) > width {
+			width = len(codes[i])
+		}
+	}
+
+	glyph := func(l *heatLevel) string {
+		if ascii {
+			return l.ascii
+		}
+
+		return text.Colors{l.color}.Sprint(l.glyph)
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%-*s", familyWidth, "family")
+
+	for _, code := range codes {
+		fmt.Fprintf(&b, " %*s", width, code)
+	}
+
+	b.WriteString("\n")
+
+	for i, family := range h.Families {
+		fmt.Fprintf(&b, "%-*s", familyWidth, family)
+
+		for _, value := range h.Cells[i] {
+			cell := "  "
+			if value >= 0 {
+				cell = glyph(heatLevelOf(value))
+			}
+
+			fmt.Fprintf(&b, " %s%s", strings.Repeat(" ", width-2), cell) //nolint:gomnd
+		}
+
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\ninterruption frequency:")
+
+	for i := range heatLevels {
+		fmt.Fprintf(&b, " %s %s", glyph(&heatLevels[i]), heatLevels[i].legend)
+	}
+
+	fmt.Println(b.String())
+}
+
+func printHeatmapHTML(h *spot.Heatmap) {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Spot interruption frequency heatmap</title>\n")
+	b.WriteString("<style>table{border-collapse:collapse;font-family:sans-serif;font-size:12px}" +
+		"td,th{border:1px solid #ddd;padding:2px 6px;text-align:center}th{writing-mode:vertical-rl}</style>\n")
+	b.WriteString("</head>\n<body>\n<table>\n<tr><th>family</th>")
+
+	for _, region := range h.Regions {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(region))
+	}
+
+	b.WriteString("</tr>\n")
+
+	for i, family := range h.Families {
+		fmt.Fprintf(&b, "<tr><td>%s</td>", html.EscapeString(family))
+
+		for j, value := range h.Cells[i] {
+			if value < 0 {
+				b.WriteString("<td></td>")
+
+				continue
+			}
+
+			l := heatLevelOf(value)
+			fmt.Fprintf(&b, "<td style=\"background:%s\" title=\"%s %s: %.1f%%\">%s</td>",
+				l.html, html.EscapeString(family), html.EscapeString(h.Regions[j]), value, html.EscapeString(l.legend))
+		}
+
+		b.WriteString("</tr>\n")
+	}
+
+	b.WriteString("</table>\n</body>\n</html>")
+	fmt.Println(b.String())
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+const defaultCheckpointInterval = time.Hour
+
+// bandLoss expected lost compute per instance per month in interruption range
+type bandLoss struct {
+	Range         spot.Range `json:"range"`
+	Interruptions float64    `json:"interruptions"`
+	LostHours     float64    `json:"lost_hours"` //nolint:tagliatelle
+}
+
+// instanceLoss expected lost compute per instance per month for instance type in region
+type instanceLoss struct {
+	Instance  string     `json:"instance"`
+	Region    string     `json:"region"`
+	Range     spot.Range `json:"range"`
+	Price     float64    `json:"price"`
+	LostHours float64    `json:"lost_hours"` //nolint:tagliatelle
+	LostCost  float64    `json:"lost_cost"`  //nolint:tagliatelle
+}
+
+func insuranceCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "insurance",
+		Usage: "estimate compute lost to interruptions per month at each interruption range",
+		Description: `Work is assumed to be checkpointed every --checkpoint interval, so each interruption loses
+half of the interval on average plus --restart time. The expected interruption rate is the middle of
+the interruption range (percent of instances per month). Lost cost is paid at spot price.
+
+   spotinfo insurance --checkpoint=30m --restart=10m --type="^m5\.(x|2x)large$" --region=eu-west-1`,
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:  "checkpoint",
+				Usage: "checkpoint interval (e.g. 15m, 1h)",
+				Value: defaultCheckpointInterval,
+			},
+			&cli.DurationFlag{
+				Name:  "restart",
+				Usage: "time to restart from the last checkpoint after interruption",
+				Value: defaultRestartCost,
+			},
+			&cli.StringFlag{
+				Name:  "os",
+				Usage: "instance operating system (windows/linux)",
+				Value: "linux",
+			},
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "set one or more AWS regions",
+				Value: cli.NewStringSlice("us-east-1"),
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "EC2 instance type (can be RE2 regexp patten); show lost cost per instance type",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: table|json",
+				Value: "table",
+			},
+		},
+		Action: insuranceCmd,
+	}
+}
+
+func insuranceCmd(c *cli.Context) error {
+	checkpoint, restart := c.Duration("checkpoint"), c.Duration("restart")
+	if checkpoint < 0 || restart < 0 {
+		return errors.New("checkpoint interval and restart time must not be negative")
+	}
+
+	advices, err := spot.GetSpotSavingsContext(c.Context, c.StringSlice("region"), c.String("type"), c.String("os"), 0, 0, 0, spot.SortByRange, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get spot savings")
+	}
+
+	// interruption ranges present in results, from least to most frequent
+	seen := make(map[spot.Range]bool)
+
+	var bands []bandLoss
+
+	for _, a := range advices {
+		if !seen[a.Range] {
+			seen[a.Range] = true
+			bands = append(bands, bandLoss{
+				Range:         a.Range,
+				Interruptions: a.Range.Mid() / 100, //nolint:gomnd
+				LostHours:     spot.LostHours(a.Range, checkpoint, restart),
+			})
+		}
+	}
+
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Range.Max < bands[j].Range.Max })
+
+	var instances []instanceLoss
+
+	if c.String("type") != "" {
+		for i := range advices {
+			a := &advices[i]
+			instances = append(instances, instanceLoss{
+				Instance:  a.Instance,
+				Region:    a.Region,
+				Range:     a.Range,
+				Price:     a.Price,
+				LostHours: spot.LostHours(a.Range, checkpoint, restart),
+				LostCost:  a.LostCost(checkpoint, restart),
+			})
+		}
+	}
+
+	if c.String("output") == "json" {
+		printAdvicesJSON(struct {
+			Bands     []bandLoss     `json:"bands"`
+			Instances []instanceLoss `json:"instances,omitempty"`
+		}{bands, instances})
+
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{interruptionColumn, "Interruptions/Instance/Month", "Lost Hours/Instance/Month"})
+
+	for _, b := range bands {
+		t.AppendRow(table.Row{b.Range.Label, fmt.Sprintf("%.3f", b.Interruptions), fmt.Sprintf("%.3f", b.LostHours)})
+	}
+
+	t.SetStyle(tableStyle(c.String("theme")))
+	t.Render()
+
+	if len(instances) == 0 {
+		return nil
+	}
+
+	t = table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{instanceTypeColumn, regionColumn, interruptionColumn, priceColumn, "Lost Hours/Month", "Lost USD/Month"})
+
+	for _, l := range instances {
+		t.AppendRow(table.Row{l.Instance, l.Region, l.Range.Label, fmt.Sprintf("%.4f", l.Price),
+			fmt.Sprintf("%.3f", l.LostHours), fmt.Sprintf("%.4f", l.LostCost)})
+	}
+
+	t.SetStyle(tableStyle(c.String("theme")))
+	t.Render()
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// numberFormat locale decimal separator and digit grouping
+type numberFormat struct {
+	decimal string
+	group   string
+}
+
+// number formats by language, or by language_TERRITORY for exceptions
+var numberFormats = map[string]numberFormat{
+	"en":    {decimal: ".", group: ","},
+	"ja":    {decimal: ".", group: ","},
+	"ko":    {decimal: ".", group: ","},
+	"zh":    {decimal: ".", group: ","},
+	"de":    {decimal: ",", group: "."},
+	"da":    {decimal: ",", group: "."},
+	"es":    {decimal: ",", group: "."},
+	"id":    {decimal: ",", group: "."},
+	"it":    {decimal: ",", group: "."},
+	"nl":    {decimal: ",", group: "."},
+	"pt":    {decimal: ",", group: "."},
+	"tr":    {decimal: ",", group: "."},
+	"cs":    {decimal: ",", group: " "},
+	"fi":    {decimal: ",", group: " "},
+	"fr":    {decimal: ",", group: " "},
+	"nb":    {decimal: ",", group: " "},
+	"pl":    {decimal: ",", group: " "},
+	"ru":    {decimal: ",", group: " "},
+	"sv":    {decimal: ",", group: " "},
+	"uk":    {decimal: ",", group: " "},
+	"de_CH": {decimal: ".", group: "'"},
+}
+
+// newNumberFormat number format for locale, e.g. "de", "de_DE", "fr-FR" or "pt_BR.UTF-8"
+func newNumberFormat(locale string) (*numberFormat, error) {
+	name := strings.SplitN(locale, ".", 2)[0] //nolint:gomnd
+	name = strings.ReplaceAll(name, "-", "_")
+
+	if f, ok := numberFormats[name]; ok {
+		return &f, nil
+	}
+
+	if f, ok := numberFormats[strings.ToLower(strings.SplitN(name, "_", 2)[0])]; ok { //nolint:gomnd
+		return &f, nil
+	}
+
+	return nil, errors.Errorf("unsupported locale %q", locale)
+}
+
+// localize canonical formatted number, e.g. "1234.5" to "1.234,5" in German
+func (f *numberFormat) localize(number string) string {
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	intPart, fracPart := number, ""
+	if i := strings.Index(number, "."); i >= 0 {
+		intPart, fracPart = number[:i], number[i+1:]
+	}
+
+	// group integer digits by thousands
+	const groupSize = 3
+
+	var b strings.Builder
+
+	for i, d := range intPart {
+		if i > 0 && (len(intPart)-i)%groupSize == 0 {
+			b.WriteString(f.group)
+		}
+
+		b.WriteRune(d)
+	}
+
+	if fracPart != "" {
+		b.WriteString(f.decimal)
+		b.WriteString(fracPart)
+	}
+
+	return sign + b.String()
+}
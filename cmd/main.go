@@ -9,8 +9,11 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"spotinfo/public/spot" //nolint:gci
 
@@ -41,14 +44,70 @@ const (
 	savingsColumn      = "Savings over On-Demand"
 	interruptionColumn = "Frequency of interruption"
 	priceColumn        = "USD/Hour"
+	complianceColumn   = "Compliance"
+	monthlyColumn      = "Savings USD/Month"
+	generationColumn   = "Generation"
+	annotationsColumn  = "Annotations"
 )
 
+const (
+	// default number of decimal places of displayed prices
+	defaultPricePrecision = 4
+	maxPricePrecision     = 10
+)
+
+// outputOptions optional columns to print
+type outputOptions struct {
+	region     bool
+	compliance bool
+	provenance bool
+	monthly    bool
+	generation bool
+	theme      string
+	numbers    *numberFormat // locale number format for human readable output, nil for canonical
+	precision  int           // decimal places of displayed prices
+	warnings   []string      // skipped regions, included in JSON output with --provenance
+	csv        csvFormat
+}
+
+// price format price for human readable output
+func (o *outputOptions) price(v float64) string {
+	return o.localize(strconv.FormatFloat(v, 'f', o.precision, 64))
+}
+
+// localize canonical formatted number with locale number format, if any
+func (o *outputOptions) localize(number string) string {
+	if o.numbers == nil {
+		return number
+	}
+
+	return o.numbers.localize(number)
+}
+
+// provenance data sources of the results, included in JSON output with --provenance
+type provenance struct {
+	Version     string                `json:"version"`
+	BuildDate   string                `json:"build_date"`                   //nolint:tagliatelle
+	DataDate    string                `json:"embedded_data_date,omitempty"` //nolint:tagliatelle
+	GeneratedAt time.Time             `json:"generated_at"`                 //nolint:tagliatelle
+	Feeds       []spot.FeedProvenance `json:"feeds"`
+}
+
 //nolint:cyclop
 func mainCmd(c *cli.Context) error {
 	if v := mainCtx.Value("key"); v != nil {
 		log.Printf("context value = %v", v)
 	}
 
+	// project defaults pinned in workspace config
+	workspaceConfig, err := applyWorkspaceConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// flags also accepted after command name (spotinfo advise --strict), not seen by app Before
+	applyGlobalFlags(c)
+
 	regions := c.StringSlice("region")
 	instanceOS := c.String("os")
 	instance := c.String("type")
@@ -59,63 +118,613 @@ func mainCmd(c *cli.Context) error {
 	order := c.String("order")
 	sortDesc := strings.EqualFold(order, "desc")
 
-	var sort int
+	ctx := mainCtx
 
-	switch sortBy {
-	case "type":
-		sort = spot.SortByInstance
-	case "interruption":
-		sort = spot.SortByRange
-	case "savings":
-		sort = spot.SortBySavings
-	case "price":
-		sort = spot.SortByPrice
-	case "region":
-		sort = spot.SortByRegion
-	default:
-		sort = spot.SortByRange
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	// get spot savings
-	advices, err := spot.GetSpotSavings(regions, instance, instanceOS, cpu, memory, maxPrice, sort, sortDesc)
+	spot.SetFetchTimeout(c.Duration("fetch-timeout"))
+
+	if err := validateTheme(c.String("theme")); err != nil {
+		return err
+	}
+
+	setColors(c.Bool("no-color"))
+
+	delimiter, err := parseCSVDelimiter(c.String("csv-delimiter"))
 	if err != nil {
+		return err
+	}
+
+	if precision := c.Int("precision"); precision < 0 || precision > maxPricePrecision {
+		return errors.Errorf("invalid price precision %d, must be 0-%d", precision, maxPricePrecision)
+	}
+
+	var numbers *numberFormat
+
+	if locale := c.String("locale"); locale != "" {
+		var err error
+		if numbers, err = newNumberFormat(locale); err != nil {
+			return err
+		}
+	}
+
+	if err := setDataChecksums(c.String("data-checksums")); err != nil {
+		return err
+	}
+
+	sort := sortByName(sortBy)
+
+	regionsSet := c.IsSet("region")
+
+	// natural-language query: explicitly set flags take precedence
+	if ask := c.String("ask"); ask != "" {
+		query, err := spot.ParseQuery(ctx, ask)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse query")
+		}
+
+		fmt.Fprintf(os.Stderr, "query: %s\n", query)
+
+		if len(query.Regions) > 0 && !regionsSet {
+			regions = query.Regions
+			regionsSet = true
+		}
+
+		if query.OS != "" && !c.IsSet("os") {
+			instanceOS = query.OS
+		}
+
+		if query.Pattern != "" && !c.IsSet("type") {
+			instance = query.Pattern
+		}
+
+		if query.CPU != 0 && !c.IsSet("cpu") {
+			cpu = query.CPU
+		}
+
+		if query.Memory != 0 && !c.IsSet("memory") {
+			memory = query.Memory
+		}
+
+		if query.Price != 0 && !c.IsSet("price") {
+			maxPrice = query.Price
+		}
+
+		if !c.IsSet("sort") {
+			sort = query.SortBy
+		}
+
+		if !c.IsSet("order") {
+			sortDesc = query.SortDesc
+		}
+	}
+
+	// ask for regions instead of silently using the default region
+	if !regionsSet && !c.Bool("yes") && interactive() {
+		var err error
+		if regions, err = promptRegions(ctx, os.Stdin, os.Stderr); err != nil {
+			return err
+		}
+	}
+
+	var opts []spot.Option
+	if compliance := c.StringSlice("compliance"); len(compliance) > 0 {
+		opts = append(opts, spot.WithCompliance(compliance...))
+	}
+
+	if c.Bool("modern-only") {
+		opts = append(opts, spot.WithCurrentGeneration())
+	}
+
+	if c.Bool("efa") {
+		opts = append(opts, spot.WithTags(spot.TagEFA))
+	}
+
+	if c.Bool("sap-certified") {
+		opts = append(opts, spot.WithTags(spot.TagSAPCertified))
+	}
+
+	if c.Bool("hibernate-capable") {
+		opts = append(opts, spot.WithTags(spot.TagHibernate))
+	}
+
+	if flags := c.StringSlice("exclude-flag"); len(flags) > 0 {
+		if err := validateQualityFlags(flags); err != nil {
+			return err
+		}
+
+		opts = append(opts, spot.WithoutFlags(flags...))
+	}
+
+	if values := c.StringSlice("annotate"); len(values) > 0 {
+		annotations, err := parseKeyValues("annotation", values)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, spot.WithEnricher(spot.EnricherFunc(func(ctx context.Context, advice *spot.Advice) error {
+			for key, value := range annotations {
+				advice.Annotate(key, value)
+			}
+
+			return nil
+		})))
+	}
+
+	if c.Bool("price-list") {
+		priceList, err := priceListOption(instanceOS)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, priceList)
+	}
+
+	if c.IsSet("interruption-penalty") {
+		opts = append(opts, spot.WithInterruptionPenalty(c.Float64("interruption-penalty")))
+	}
+
+	var exclusions []spot.Exclusion
+	if c.Bool("explain") {
+		opts = append(opts, spot.WithExclusionHandler(func(e spot.Exclusion) {
+			exclusions = append(exclusions, e)
+		}))
+	}
+
+	if c.Bool("progress") {
+		opts = append(opts, spot.WithProgress(progressPrinter()))
+	}
+
+	// skip failed regions with a warning, unless asked to fail
+	var regionErrors []spot.RegionError
+	if !c.Bool("fail-fast") && !c.Bool("strict") {
+		opts = append(opts, spot.WithRegionErrorHandler(func(e spot.RegionError) {
+			regionErrors = append(regionErrors, e)
+		}))
+	}
+
+	if c.Bool("dry-run") {
+		printDryRun(c, &spot.Query{
+			Regions: regions, Pattern: instance, OS: instanceOS, CPU: cpu, Memory: memory, Price: maxPrice, SortBy: sort, SortDesc: sortDesc,
+		})
+
+		if workspaceConfig != "" {
+			fmt.Printf("workspace config: %s\n", workspaceConfig)
+		}
+
+		return nil
+	}
+
+	if c.Bool("progress") {
+		fmt.Fprintln(os.Stderr, "loading data feeds ...")
+	}
+
+	// get spot savings
+	advices, err := spot.GetSpotSavingsContext(ctx, regions, instance, instanceOS, cpu, memory, maxPrice, sort, sortDesc, opts...)
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Printf("warning: timeout reached, showing partial results: %v", err)
+	} else if err != nil {
 		return errors.Wrap(err, "failed to get spot savings")
 	}
 
-	// decide if region should be printed
-	printRegion := len(regions) > 1 || (len(regions) == 1 && regions[0] == "all")
+	if len(regionErrors) > 0 {
+		queried := regions
+		if len(regions) == 1 && regions[0] == "all" {
+			if queried, err = spot.Regions(ctx); err != nil {
+				return errors.Wrap(err, "failed to list regions")
+			}
+		}
+
+		if len(regionErrors) == len(queried) {
+			return errors.Wrap(regionErrors[0], "failed to get spot savings")
+		}
+	}
+
+	warnings := make([]string, 0, len(regionErrors))
+	for _, e := range regionErrors {
+		warnings = append(warnings, e.Error())
+		log.Printf("warning: skipped %v", e)
+	}
+
+	if c.Bool("accelerator-alternatives") {
+		if advices, err = withAcceleratorAlternatives(ctx, advices, regions, instanceOS, maxPrice, sort, sortDesc, opts...); err != nil {
+			return err
+		}
+	}
+
+	warnCoverage(ctx, regions)
+
+	if err = checkEmbeddedDataAge(c.Int("max-data-age")); err != nil {
+		return err
+	}
+
+	out := outputOptions{
+		// decide if region should be printed
+		region:     len(regions) > 1 || (len(regions) == 1 && regions[0] == "all"),
+		compliance: c.IsSet("compliance"),
+		provenance: c.Bool("provenance"),
+		monthly:    c.Bool("monthly-savings"),
+		generation: c.Bool("generation"),
+		theme:      c.String("theme"),
+		numbers:    numbers,
+		precision:  c.Int("precision"),
+		warnings:   warnings,
+		csv:        csvFormat{delimiter: delimiter, noHeader: c.Bool("csv-no-header")},
+	}
+
+	if chart := c.String("helm-values"); chart != "" || c.Bool(spotFleetGenerator) {
+		tags, err := parseKeyValues("tag", c.StringSlice("tags"))
+		if err != nil {
+			return err
+		}
+
+		// AWS tags cannot contain commas: catch --tags a=1,b=2 instead of creating tag a with value "1,b=2"
+		for key, value := range tags {
+			if strings.Contains(key, ",") || strings.Contains(value, ",") {
+				return errors.Errorf("invalid tag %s=%s, repeat --tags for each KEY=VALUE tag", key, value)
+			}
+		}
+
+		strategy := c.String("allocation-strategy")
+		if err = allocationOrder(advices, strategy, c.Float64("interruption-penalty")); err != nil {
+			return err
+		}
+
+		if c.Bool(spotFleetGenerator) {
+			err = printSpotFleetInput(advices, strategy, tags)
+		} else {
+			err = printHelmValues(advices, chart, strategy, tags)
+		}
+
+		if err != nil {
+			return err
+		}
+	} else {
+		printAdvices(advices, c.String("output"), out)
+	}
+
+	if c.Bool("snapshot") {
+		id, err := saveSnapshot(advices, flagArgs(c, advisorFlags()))
+		if err != nil {
+			return err
+		}
+
+		log.Printf("saved snapshot %s", id)
+	}
+
+	if c.Bool("explain") {
+		printExplain(advices, exclusions, sort, sortDesc, c.Float64("interruption-penalty"))
+	}
 
-	switch c.String("output") {
+	return nil
+}
+
+func printAdvices(advices []spot.Advice, format string, out outputOptions) {
+	switch format {
 	case "number":
-		printAdvicesNumber(advices, printRegion)
+		printAdvicesNumber(advices, out)
 	case "text":
-		printAdvicesText(advices, printRegion)
+		printAdvicesText(advices, out)
 	case "json":
-		printAdvicesJSON(advices)
-	case "table":
-		printAdvicesTable(advices, false, printRegion)
-	case "csv":
-		printAdvicesTable(advices, true, printRegion)
+		if out.provenance {
+			printAdvicesJSON(struct {
+				Provenance provenance    `json:"provenance"`
+				Warnings   []string      `json:"warnings,omitempty"`
+				Advices    []spot.Advice `json:"advices"`
+			}{
+				Warnings: out.warnings,
+				Provenance: provenance{
+					Version:     Version,
+					BuildDate:   BuildDate,
+					DataDate:    spot.EmbeddedDataDate,
+					GeneratedAt: time.Now().UTC(),
+					Feeds:       spot.Provenance(),
+				},
+				Advices: advices,
+			})
+		} else {
+			printAdvicesJSON(advices)
+		}
+	case "table", "csv", "tsv", "plain":
+		printAdvicesTable(advices, format, out)
+	case "slack":
+		printAdvicesSlack(advices, out)
 	default:
-		printAdvicesNumber(advices, printRegion)
+		printAdvicesNumber(advices, out)
+	}
+}
+
+// checkEmbeddedDataAge warn when results are based on old embedded data; fail if older than maxDays (if set)
+func checkEmbeddedDataAge(maxDays int) error {
+	const (
+		day     = 24 * time.Hour
+		warnAge = 30 * day
+	)
+
+	embedded := false
+
+	for _, feed := range spot.Provenance() {
+		embedded = embedded || feed.Embedded
+	}
+
+	if !embedded {
+		return nil
+	}
+
+	age, err := spot.EmbeddedDataAge()
+	if err != nil {
+		if maxDays > 0 {
+			return errors.Wrap(err, "cannot verify embedded data age")
+		}
+
+		return nil
+	}
+
+	if maxDays > 0 && age > time.Duration(maxDays)*day {
+		return errors.Errorf("embedded data is %d days old (from %s), exceeds --max-data-age=%d", int(age/day), displayDate(spot.EmbeddedDataDate), maxDays)
+	}
+
+	if age > warnAge {
+		log.Printf("warning: using embedded data from %s (%d days old), data feeds are not available", displayDate(spot.EmbeddedDataDate), int(age/day))
 	}
 
 	return nil
 }
 
-func printAdvicesText(advices []spot.Advice, region bool) {
-	for _, advice := range advices {
-		if region {
-			fmt.Printf("region=%s, type=%s, vCPU=%d, memory=%vGiB, saving=%d%%, interruption='%s', price=%.2f\n",
-				advice.Region, advice.Instance, advice.Info.Cores, advice.Info.RAM, advice.Savings, advice.Range.Label, advice.Price)
+// setDataChecksums require downloaded data feeds to match checksums from file (sha256sum format)
+func setDataChecksums(file string) error {
+	if file == "" {
+		return nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrap(err, "failed to open data checksums file")
+	}
+	defer f.Close() //nolint:errcheck
+
+	checksums, err := spot.ParseChecksums(f)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse data checksums file")
+	}
+
+	spot.SetFeedChecksums(checksums)
+
+	return nil
+}
+
+// sortByName convert --sort flag value to sort type (default: by interruption range)
+func sortByName(name string) int {
+	switch name {
+	case "type":
+		return spot.SortByInstance
+	case "interruption":
+		return spot.SortByRange
+	case "savings":
+		return spot.SortBySavings
+	case "price":
+		return spot.SortByPrice
+	case "region":
+		return spot.SortByRegion
+	case "adjusted-price":
+		return spot.SortByAdjustedPrice
+	default:
+		return spot.SortByRange
+	}
+}
+
+// printExplain explain result ranking and excluded instances (to stderr, keeping stdout parseable)
+func printExplain(advices []spot.Advice, exclusions []spot.Exclusion, sortBy int, sortDesc bool, penalty float64) {
+	const (
+		topResults       = 10
+		exclusionSamples = 5
+	)
+
+	order := "ascending"
+	if sortDesc {
+		order = "descending"
+	}
+
+	var key func(a *spot.Advice) string
+
+	switch sortBy {
+	case spot.SortByInstance:
+		key = func(a *spot.Advice) string { return "type=" + a.Instance }
+	case spot.SortBySavings:
+		key = func(a *spot.Advice) string { return fmt.Sprintf("savings=%d%%", a.Savings) }
+	case spot.SortByPrice:
+		key = func(a *spot.Advice) string { return fmt.Sprintf("price=%v", a.Price) }
+	case spot.SortByRegion:
+		key = func(a *spot.Advice) string { return "region=" + a.Region }
+	case spot.SortByAdjustedPrice:
+		key = func(a *spot.Advice) string {
+			return fmt.Sprintf("adjusted price=%.4f (price=%v, interruption=%s)", a.AdjustedPrice(penalty), a.Price, a.Range.Label)
+		}
+	default:
+		key = func(a *spot.Advice) string {
+			return fmt.Sprintf("interruption=%s (min %d%%)", a.Range.Label, a.Range.Min)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "explain: %d results ranked by a single sort key, %s\n", len(advices), order)
+
+	for i := range advices {
+		if i == topResults {
+			break
+		}
+
+		fmt.Fprintf(os.Stderr, "  #%d %s/%s: %s\n", i+1, advices[i].Region, advices[i].Instance, key(&advices[i]))
+	}
+
+	if len(exclusions) == 0 {
+		fmt.Fprintln(os.Stderr, "explain: no matching instances were excluded by filters")
+
+		return
+	}
+
+	// group exclusions by filter, keeping first seen order
+	var filters []string
+
+	byFilter := make(map[string][]spot.Exclusion)
+
+	for _, e := range exclusions {
+		if _, ok := byFilter[e.Filter]; !ok {
+			filters = append(filters, e.Filter)
+		}
+
+		byFilter[e.Filter] = append(byFilter[e.Filter], e)
+	}
+
+	fmt.Fprintf(os.Stderr, "explain: %d instances/regions excluded by filters\n", len(exclusions))
+
+	for _, f := range filters {
+		fmt.Fprintf(os.Stderr, "  %s: %d excluded\n", f, len(byFilter[f]))
+
+		for i, e := range byFilter[f] {
+			if i == exclusionSamples {
+				fmt.Fprintln(os.Stderr, "    ...")
+
+				break
+			}
+
+			name := e.Region
+			if e.Instance != "" {
+				name += "/" + e.Instance
+			}
+
+			fmt.Fprintf(os.Stderr, "    %s: %s\n", name, e.Reason)
+		}
+	}
+}
+
+func printDryRun(c *cli.Context, query *spot.Query) {
+	fmt.Println("dry run: no data is fetched and no query is executed")
+	fmt.Println("data feeds:")
+
+	for _, feed := range spot.DataFeeds() {
+		if dir := os.Getenv(spot.ReplayEnv); dir != "" {
+			fmt.Printf("  %s: %s (replayed from %s)\n", feed.Name, feed.URL, dir)
+		} else if c.Bool("strict") {
+			fmt.Printf("  %s: %s (timeout %v, strict: no embedded data fallback)\n", feed.Name, feed.URL, c.Duration("fetch-timeout"))
 		} else {
-			fmt.Printf("type=%s, vCPU=%d, memory=%vGiB, saving=%d%%, interruption='%s', price=%.2f\n",
-				advice.Instance, advice.Info.Cores, advice.Info.RAM, advice.Savings, advice.Range.Label, advice.Price)
+			fmt.Printf("  %s: %s (timeout %v, embedded data fallback)\n", feed.Name, feed.URL, c.Duration("fetch-timeout"))
 		}
 	}
+
+	if dir := os.Getenv(spot.RecordEnv); dir != "" && os.Getenv(spot.ReplayEnv) == "" {
+		fmt.Printf("  responses recorded to: %s\n", dir)
+	}
+
+	fmt.Println("AWS API calls: none")
+	fmt.Printf("query: %s\n", query)
+
+	if compliance := c.StringSlice("compliance"); len(compliance) > 0 {
+		fmt.Printf("compliance: %s\n", strings.Join(compliance, ","))
+	}
+
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		fmt.Printf("timeout: %v\n", timeout)
+	}
+
+	fmt.Printf("output: %s\n", c.String("output"))
+}
+
+func printAdvicesText(advices []spot.Advice, opts outputOptions) {
+	for _, advice := range advices {
+		line := fmt.Sprintf("type=%s, vCPU=%d, memory=%sGiB, saving=%d%%, interruption='%s', price=%s",
+			advice.Instance, advice.Info.Cores, opts.localize(fmt.Sprint(advice.Info.RAM)), advice.Savings, advice.Range.Label,
+			opts.price(advice.Price))
+		if opts.region {
+			line = fmt.Sprintf("region=%s, %s", advice.Region, line)
+		}
+
+		if opts.compliance {
+			line = fmt.Sprintf("%s, compliance=%s", line, strings.Join(advice.Compliance, "|"))
+		}
+
+		if opts.generation {
+			line = fmt.Sprintf("%s, generation=%s", line, advice.Generation)
+		}
+
+		if opts.monthly {
+			line = fmt.Sprintf("%s, monthly_savings=%s", line, opts.localize(fmt.Sprintf("%.2f", advice.MonthlySavings())))
+		}
+
+		if len(advice.Flags) > 0 {
+			line = fmt.Sprintf("%s, flags=%s", line, strings.Join(advice.Flags, "|"))
+		}
+
+		if len(advice.Annotations) > 0 {
+			line = fmt.Sprintf("%s, annotations=%s", line, formatAnnotations(advice.Annotations))
+		}
+
+		fmt.Println(line)
+	}
+}
+
+// validateQualityFlags check data quality flag names
+func validateQualityFlags(flags []string) error {
+	known := []string{spot.FlagPriceMissing, spot.FlagAdvisorOnly, spot.FlagInfoMissing, spot.FlagEmbeddedData}
+
+	for _, flag := range flags {
+		valid := false
+
+		for _, k := range known {
+			valid = valid || flag == k
+		}
+
+		if !valid {
+			return errors.Errorf("invalid data quality flag %q, must be %s", flag, strings.Join(known, "/"))
+		}
+	}
+
+	return nil
+}
+
+func hasAnnotations(advices []spot.Advice) bool {
+	for i := range advices {
+		if len(advices[i].Annotations) > 0 {
+			return true
+		}
+	}
+
+	return false
 }
 
-func printAdvicesNumber(advices []spot.Advice, region bool) {
+// formatAnnotations format annotations as "key=value" list sorted by key
+func formatAnnotations(annotations map[string]string) string {
+	items := make([]string, 0, len(annotations))
+	for key, value := range annotations {
+		items = append(items, key+"="+value)
+	}
+
+	sort.Strings(items)
+
+	return strings.Join(items, ";")
+}
+
+// parseKeyValues parse KEY=VALUE pairs (annotations, tags); kind is used in error message
+func parseKeyValues(kind string, values []string) (map[string]string, error) {
+	pairs := make(map[string]string, len(values))
+
+	for _, v := range values {
+		i := strings.Index(v, "=")
+		if i <= 0 {
+			return nil, errors.Errorf("invalid %s %q, use KEY=VALUE", kind, v)
+		}
+
+		pairs[v[:i]] = v[i+1:]
+	}
+
+	return pairs, nil
+}
+
+func printAdvicesNumber(advices []spot.Advice, opts outputOptions) {
 	if len(advices) == 1 {
 		fmt.Println(advices[0].Savings)
 
@@ -123,7 +732,7 @@ func printAdvicesNumber(advices []spot.Advice, region bool) {
 	}
 
 	for _, advice := range advices {
-		if region {
+		if opts.region {
 			fmt.Printf("%s/%s: %d\n", advice.Region, advice.Instance, advice.Savings)
 		} else {
 			fmt.Printf("%s: %d\n", advice.Instance, advice.Savings)
@@ -143,35 +752,109 @@ func printAdvicesJSON(advices interface{}) {
 	fmt.Println(txt)
 }
 
-func printAdvicesTable(advices []spot.Advice, csv, region bool) {
+// printAdvicesTable print advices as pretty table, plain (aligned, no borders), CSV or TSV
+func printAdvicesTable(advices []spot.Advice, format string, opts outputOptions) {
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
 
 	header := table.Row{instanceTypeColumn, vCPUColumn, memoryColumn, savingsColumn, interruptionColumn, priceColumn}
-	if region {
+	if opts.region {
 		header = append(table.Row{regionColumn}, header...)
 	}
 
+	if opts.compliance {
+		header = append(header, complianceColumn)
+	}
+
+	if opts.generation {
+		header = append(header, generationColumn)
+	}
+
+	if opts.monthly {
+		header = append(header, monthlyColumn)
+	}
+
+	annotations := hasAnnotations(advices)
+	if annotations {
+		header = append(header, annotationsColumn)
+	}
+
 	t.AppendHeader(header)
 
-	for _, advice := range advices {
+	// CSV, TSV and plain numbers stay canonical
+	canonical := format != "table"
+	if canonical {
+		opts.numbers = nil
+	}
+
+	var (
+		totalMonthly float64
+		rows         []table.Row
+	)
+
+	for i, advice := range advices {
 		row := table.Row{advice.Instance, advice.Info.Cores, advice.Info.RAM, advice.Savings, advice.Range.Label, advice.Price}
-		if region {
+		// CSV, TSV and plain keep full precision canonical numbers
+		if !canonical {
+			row[2] = opts.localize(fmt.Sprint(advice.Info.RAM))
+			row[5] = opts.price(advice.Price)
+		}
+
+		if opts.region {
 			row = append(table.Row{advice.Region}, row...)
 		}
 
-		t.AppendRow(row)
+		if opts.compliance {
+			row = append(row, strings.Join(advice.Compliance, ", "))
+		}
+
+		if opts.generation {
+			row = append(row, advice.Generation)
+		}
+
+		if opts.monthly {
+			monthly := advices[i].MonthlySavings()
+			totalMonthly += monthly
+			row = append(row, opts.localize(fmt.Sprintf("%.2f", monthly)))
+		}
+
+		if annotations {
+			row = append(row, formatAnnotations(advice.Annotations))
+		}
+
+		rows = append(rows, row)
 	}
-	// render as CSV
-	if csv {
-		fmt.Println("rendering CSV")
-		t.RenderCSV()
-	} else { // render as pretty table
-		t.SetColumnConfigs([]table.ColumnConfig{{
-			Name:        savingsColumn,
-			Transformer: text.NewNumberTransformer("%d%%"),
-		}})
-		t.SetStyle(table.StyleLight)
+	switch format {
+	case "csv":
+		printCSV(os.Stdout, header, rows, opts.csv)
+	case "tsv":
+		printTSV(os.Stdout, header, rows)
+	case "plain":
+		t.AppendRows(rows)
+		t.SetStyle(plainStyle())
+		t.Render()
+	default: // render as pretty table
+		t.AppendRows(rows)
+		t.SetColumnConfigs([]table.ColumnConfig{
+			{Name: savingsColumn, Transformer: text.NewNumberTransformer("%d%%")},
+			// localized numbers are strings: keep them aligned as numbers
+			{Name: memoryColumn, Align: text.AlignRight},
+			{Name: priceColumn, Align: text.AlignRight},
+			{Name: monthlyColumn, Align: text.AlignRight, AlignFooter: text.AlignRight},
+		})
+		// total monthly savings of all results in the last column
+		if opts.monthly {
+			footer := make(table.Row, len(header))
+			for i := range footer {
+				footer[i] = ""
+			}
+
+			footer[0] = "Total"
+			footer[len(footer)-1] = opts.localize(fmt.Sprintf("%.2f", totalMonthly))
+			t.AppendFooter(footer)
+		}
+
+		t.SetStyle(tableStyle(opts.theme))
 		t.Style().Options.SeparateRows = true
 		t.Render()
 	}
@@ -202,61 +885,246 @@ func handleSignals() context.Context {
 	return ctx
 }
 
-func main() {
-	app := &cli.App{
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:  "type",
-				Usage: "EC2 instance type (can be RE2 regexp patten)",
-			},
-			&cli.StringFlag{
-				Name:  "os",
-				Usage: "instance operating system (windows/linux)",
-				Value: "linux",
-			},
-			&cli.StringSliceFlag{
-				Name:  "region",
-				Usage: "set one or more AWS regions, use \"all\" for all AWS regions",
-				Value: cli.NewStringSlice("us-east-1"),
-			},
-			&cli.StringFlag{
-				Name:  "output",
-				Usage: "format output: number|text|json|table|csv",
-				Value: "table",
-			},
-			&cli.IntFlag{
-				Name:  "cpu",
-				Usage: "filter: minimal vCPU cores",
-			},
-			&cli.IntFlag{
-				Name:  "memory",
-				Usage: "filter: minimal memory GiB",
-			},
-			&cli.Float64Flag{
-				Name:  "price",
-				Usage: "filter: maximum price per hour",
-			},
-			&cli.StringFlag{
-				Name:  "sort",
-				Usage: "sort results by interruption|type|savings|price|region",
-				Value: "interruption",
-			},
-			&cli.StringFlag{
-				Name:  "order",
-				Usage: "sort order asc|desc",
-				Value: "asc",
-			},
-		},
-		Name:    "spotinfo",
-		Usage:   "explore AWS EC2 Spot instances",
-		Action:  mainCmd,
-		Version: Version,
+// advisorFlags flags of spot advice query
+func advisorFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "EC2 instance type (can be RE2 regexp patten)",
+		},
+		&cli.StringFlag{
+			Name:  "os",
+			Usage: "instance operating system (windows/linux)",
+			Value: "linux",
+		},
+		&cli.StringSliceFlag{
+			Name:  "region",
+			Usage: "set one or more AWS regions, use \"all\" for all AWS regions (prompted for on terminal, unless --yes)",
+			Value: cli.NewStringSlice(defaultRegion),
+		},
+		&cli.BoolFlag{
+			Name:  "yes",
+			Usage: "do not prompt for regions on terminal, use default region when --region is not set",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "format output: number|text|json|table|csv|tsv|plain|slack",
+			Value: "table",
+		},
+		&cli.StringFlag{
+			Name:  "csv-delimiter",
+			Usage: "CSV output field delimiter: single character or tab",
+			Value: ",",
+		},
+		&cli.BoolFlag{
+			Name:  "csv-no-header",
+			Usage: "omit CSV output header line",
+		},
+		&cli.IntFlag{
+			Name:  "cpu",
+			Usage: "filter: minimal vCPU cores",
+		},
+		&cli.IntFlag{
+			Name:  "memory",
+			Usage: "filter: minimal memory GiB",
+		},
+		&cli.Float64Flag{
+			Name:  "price",
+			Usage: "filter: maximum price per hour",
+		},
+		&cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort results by interruption|type|savings|price|region|adjusted-price",
+			Value: "interruption",
+		},
+		&cli.StringFlag{
+			Name:  "order",
+			Usage: "sort order asc|desc",
+			Value: "asc",
+		},
+		&cli.Float64Flag{
+			Name:  "interruption-penalty",
+			Usage: "sort by adjusted-price: price * (1 + penalty * interruption range midpoint)",
+			Value: 1,
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "overall execution timeout, e.g. 30s (partial results are shown when reached)",
+		},
+		&cli.DurationFlag{
+			Name:  "fetch-timeout",
+			Usage: "timeout for fetching AWS data feeds",
+			Value: 10 * time.Second, //nolint:gomnd
+		},
+		&cli.StringSliceFlag{
+			Name:  "compliance",
+			Usage: "filter: regions with compliance tag gdpr|uk-gdpr|fedramp|fedramp-moderate|fedramp-high|itar|china",
+		},
+		&cli.StringFlag{
+			Name:  "data-checksums",
+			Usage: "SHA-256 checksums file (sha256sum format) downloaded data feeds must match, embedded data is used otherwise",
+		},
+		&cli.StringFlag{
+			Name:  "helm-values",
+			Usage: "print Helm values snippet with resulting instance types instead of results: karpenter|cluster-autoscaler|spark-operator",
+		},
+		&cli.StringSliceFlag{
+			Name:  "tags",
+			Usage: "cost allocation KEY=VALUE tags of resources created from generated config (e.g. --tags team=ml --tags env=prod); karpenter and spot-fleet only (spot fleet request, not its instances)",
+		},
+		&cli.BoolFlag{
+			Name:  spotFleetGenerator,
+			Usage: "print aws ec2 request-spot-fleet --cli-input-json document with resulting instance types instead of results",
+		},
+		&cli.StringFlag{
+			Name:  "allocation-strategy",
+			Usage: "order generated instance types for allocation strategy: capacity-optimized-prioritized|lowest-price|price-capacity-optimized (default: result order)",
+		},
+		&cli.BoolFlag{
+			Name:  "utc",
+			Usage: "show timestamps in UTC instead of local time (JSON output always uses RFC3339)",
+		},
+		&cli.BoolFlag{
+			Name:  "no-color",
+			Usage: "disable colored output (also disabled with NO_COLOR or when output is not a terminal)",
+		},
+		&cli.StringFlag{
+			Name:  "theme",
+			Usage: "table theme: light|dark|ascii (default: box drawing on terminal, ascii when piped)",
+		},
+		&cli.IntFlag{
+			Name:  "precision",
+			Usage: "decimal places of prices in table and text output; JSON and CSV keep full precision",
+			Value: defaultPricePrecision,
+		},
+		&cli.StringFlag{
+			Name:  "locale",
+			Usage: "format numbers in table and text output for locale (e.g. de_DE, fr); JSON and CSV stay canonical",
+		},
+		&cli.BoolFlag{
+			Name:  "modern-only",
+			Usage: "filter: exclude previous generation instance types",
+		},
+		&cli.BoolFlag{
+			Name:  "efa",
+			Usage: "filter: only instance types with Elastic Fabric Adapter support (HPC/ML)",
+		},
+		&cli.BoolFlag{
+			Name:  "sap-certified",
+			Usage: "filter: only SAP HANA certified instance families",
+		},
+		&cli.BoolFlag{
+			Name:  "accelerator-alternatives",
+			Usage: "add Inferentia/Trainium alternatives of GPU instance types to results (require AWS Neuron SDK)",
+		},
+		&cli.BoolFlag{
+			Name:  "hibernate-capable",
+			Usage: "filter: only instance types that can hibernate instead of terminate on interruption",
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude-flag",
+			Usage: "filter: drop results with data quality flag: price_missing|advisor_only|info_missing|embedded_data",
+		},
+		&cli.StringSliceFlag{
+			Name:  "annotate",
+			Usage: "add KEY=VALUE annotation to every result (e.g. recommended_for=batch), shown in all output formats",
+		},
+		&cli.BoolFlag{
+			Name:  "generation",
+			Usage: "show instance generation (current/previous)",
+		},
+		&cli.BoolFlag{
+			Name:  "progress",
+			Usage: "report per region query progress to stderr",
+		},
+		&cli.BoolFlag{
+			Name:  "monthly-savings",
+			Usage: "show savings over On-Demand in USD per month (730 hours), with total in table footer",
+		},
+		&cli.BoolFlag{
+			Name:  "price-list",
+			Usage: "use On-Demand prices from AWS Price List Bulk API for monthly savings and JSON on_demand_price, instead of deriving them from rounded savings (large download per region, cached for 7 days)",
+		},
+		&cli.BoolFlag{
+			Name:  "strict",
+			Usage: "fail instead of falling back to embedded data or showing zero price for instance types without pricing data",
+		},
+		&cli.BoolFlag{
+			Name:  "fail-fast",
+			Usage: "fail when any region query fails (implied by --strict), instead of showing other regions results with a warning",
+		},
+		&cli.IntFlag{
+			Name:  "max-data-age",
+			Usage: "fail if results would be based on embedded data older than N days",
+		},
+		&cli.BoolFlag{
+			Name:  "provenance",
+			Usage: "include data provenance (feed URLs, load times, checksums, embedded flag) in JSON output",
+		},
+		&cli.BoolFlag{
+			Name:  "explain",
+			Usage: "explain result ranking and which filters excluded matching instances (printed to stderr)",
+		},
+		&cli.BoolFlag{
+			Name:  "snapshot",
+			Usage: "save results to the local snapshot archive (see snapshots command)",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "print data feeds, API calls and effective filters without running the query",
+		},
+		&cli.StringFlag{
+			Name:  "ask",
+			Usage: "natural-language query, e.g. \"cheapest 8 vCPU ARM in Europe under $0.20\" (explicit flags take precedence)",
+		},
 	}
+}
+
+// applyGlobalFlags apply flags that set package level state: colored output, UTC timestamps and strict mode
+func applyGlobalFlags(c *cli.Context) {
+	setColors(c.Bool("no-color"))
+	utcTimes = c.Bool("utc")
+	spot.SetStrict(c.Bool("strict"))
+}
+
+func newApp() *cli.App {
+	return withExamples(&cli.App{
+		Flags: append(advisorFlags(), &cli.BoolFlag{
+			Name:  "no-update-check",
+			Usage: "do not check for a newer spotinfo release (also disabled with " + noUpdateCheckEnv + "); global option, set before command name",
+		}),
+		Commands: []*cli.Command{
+			adviseCommand(), regionsCommand(), generateCommand(),
+			queryCommand(), snapshotsCommand(), batchCommand(), bulkCommand(), analyzeTerraformCommand(),
+			simulateCommand(), insuranceCommand(), reservationCommand(), statsCommand(), heatmapCommand(),
+			coverageCommand(), dataCommand(), validateFleetCommand(), explainCommand(), docsCommand(),
+		},
+		Name:           "spotinfo",
+		Usage:          "explore AWS EC2 Spot instances",
+		Action:         mainCmd,
+		ExitErrHandler: jsonExitErrHandler,
+		Before: func(c *cli.Context) error {
+			if _, err := applyWorkspaceConfig(c); err != nil {
+				return err
+			}
+
+			applyGlobalFlags(c)
+
+			return startUpdateCheck(c)
+		},
+		After:   printUpdateNotice,
+		Version: Version,
+	})
+}
+
+func main() {
 	cli.VersionPrinter = func(c *cli.Context) {
 		fmt.Printf("spotinfo %s\n", Version)
 
+		utcTimes = c.Bool("utc")
+
 		if BuildDate != "" && BuildDate != "unknown" {
-			fmt.Printf("  Build date: %s\n", BuildDate)
+			fmt.Printf("  Build date: %s\n", displayDate(BuildDate))
 		}
 
 		if GitCommit != "" {
@@ -270,7 +1138,7 @@ func main() {
 		fmt.Printf("  Built with: %s\n", runtime.Version())
 	}
 
-	if err := app.Run(os.Args); err != nil {
+	if err := newApp().Run(os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
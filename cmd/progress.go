@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+)
+
+// isTerminal check if file is a terminal (character device)
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// progressPrinter print query progress to stderr: progress bar on terminal, line per region otherwise
+func progressPrinter() func(spot.Progress) {
+	const width = 30
+
+	tty := isTerminal(os.Stderr)
+
+	return func(p spot.Progress) {
+		if !tty {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s: %d matched\n", p.Done, p.Total, p.Region, p.Matched)
+
+			return
+		}
+
+		filled := width * p.Done / p.Total
+		fmt.Fprintf(os.Stderr, "\r[%s%s] %d/%d %-16s %4d matched",
+			strings.Repeat("#", filled), strings.Repeat(" ", width-filled), p.Done, p.Total, p.Region, p.Matched)
+
+		if p.Done == p.Total {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
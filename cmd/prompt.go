@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+)
+
+const defaultRegion = "us-east-1"
+
+// regionGroups continents of AWS regions, by region code prefix
+var regionGroups = []struct {
+	name     string
+	prefixes []string
+}{
+	{name: "North America", prefixes: []string{"us-", "ca-", "mx-"}},
+	{name: "South America", prefixes: []string{"sa-"}},
+	{name: "Europe", prefixes: []string{"eu-"}},
+	{name: "Asia Pacific", prefixes: []string{"ap-"}},
+	{name: "Middle East", prefixes: []string{"me-", "il-"}},
+	{name: "Africa", prefixes: []string{"af-"}},
+	{name: "China", prefixes: []string{"cn-"}},
+	{name: "Other", prefixes: []string{""}},
+}
+
+// promptRegions ask to select regions on terminal: numbers or region codes separated by comma/space, "all" for all
+// regions; default region when answer is empty
+func promptRegions(ctx context.Context, in io.Reader, out io.Writer) ([]string, error) {
+	regions, err := spot.Regions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list regions")
+	}
+
+	// regions in display order, grouped by continent
+	var ordered []string
+
+	grouped := make(map[string]bool, len(regions))
+
+	for _, group := range regionGroups {
+		header := false
+
+		for _, region := range regions {
+			if grouped[region] || !hasAnyPrefix(region, group.prefixes) {
+				continue
+			}
+
+			if !header {
+				fmt.Fprintf(out, "%s:\n", group.name)
+
+				header = true
+			}
+
+			grouped[region] = true
+			ordered = append(ordered, region)
+			fmt.Fprintf(out, "  %2d) %s\n", len(ordered), region)
+		}
+	}
+
+	fmt.Fprintf(out, "select regions (numbers or codes, comma separated; all for all regions) [%s]: ", defaultRegion)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrap(err, "failed to read selected regions")
+	}
+
+	return parseRegionSelection(answer, ordered)
+}
+
+func parseRegionSelection(answer string, ordered []string) ([]string, error) {
+	fields := strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' })
+	if len(fields) == 0 {
+		return []string{defaultRegion}, nil
+	}
+
+	if len(fields) == 1 && fields[0] == "all" {
+		return []string{"all"}, nil
+	}
+
+	known := make(map[string]bool, len(ordered))
+	for _, region := range ordered {
+		known[region] = true
+	}
+
+	var selected []string
+
+	for _, f := range fields {
+		if n, err := strconv.Atoi(f); err == nil {
+			if n < 1 || n > len(ordered) {
+				return nil, errors.Errorf("invalid region number %d, must be 1-%d", n, len(ordered))
+			}
+
+			selected = append(selected, ordered[n-1])
+
+			continue
+		}
+
+		if !known[f] {
+			return nil, errors.Errorf("unknown region %q", f)
+		}
+
+		selected = append(selected, f)
+	}
+
+	return selected, nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// interactive standard input and output are terminals
+func interactive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+const queriesFile = "queries.json"
+
+// savedQuery named query definition: advice query flags
+type savedQuery struct {
+	Args    []string  `json:"args"`
+	Created time.Time `json:"created"`
+}
+
+func queryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "query",
+		Usage: "save, list and run named queries",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "save",
+				Usage:     "save query flags under a name",
+				ArgsUsage: "NAME [flags]",
+				Action:    saveQueryCmd,
+			},
+			{
+				Name:      "run",
+				Usage:     "run saved query, additional flags override saved ones",
+				ArgsUsage: "NAME [flags]",
+				Action:    runQueryCmd,
+			},
+			{
+				Name:   "list",
+				Usage:  "list saved queries",
+				Action: listQueriesCmd,
+			},
+		},
+	}
+}
+
+func saveQueryCmd(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return errors.New("query name is required")
+	}
+
+	args, err := parseAdvisorArgs(c.Args().Tail())
+	if err != nil {
+		return err
+	}
+
+	queries, err := loadQueries()
+	if err != nil {
+		return err
+	}
+
+	queries[name] = savedQuery{Args: args, Created: time.Now().UTC()}
+
+	if err = storeQueries(queries); err != nil {
+		return err
+	}
+
+	fmt.Printf("saved query %s: %s\n", name, strings.Join(queries[name].Args, " "))
+
+	return nil
+}
+
+func runQueryCmd(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return errors.New("query name is required")
+	}
+
+	queries, err := loadQueries()
+	if err != nil {
+		return err
+	}
+
+	query, ok := queries[name]
+	if !ok {
+		return errors.Errorf("no saved query %q", name)
+	}
+
+	// saved flags, then additional flags overriding them, parsed into query context of this command
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	set.SetOutput(ioutil.Discard)
+
+	for _, f := range advisorFlags() {
+		if err = f.Apply(set); err != nil {
+			return errors.Wrap(err, "failed to define query flags")
+		}
+	}
+
+	if err = set.Parse(append(query.Args, c.Args().Tail()...)); err != nil {
+		return errors.Wrap(err, "invalid query flags")
+	}
+
+	if set.NArg() > 0 {
+		return errors.Errorf("unexpected arguments: %s", strings.Join(set.Args(), " "))
+	}
+
+	return mainCmd(cli.NewContext(c.App, set, c))
+}
+
+func listQueriesCmd(c *cli.Context) error {
+	queries, err := loadQueries()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(queries))
+	for name := range queries {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s: %s\n", name, strings.Join(queries[name].Args, " "))
+	}
+
+	return nil
+}
+
+// parseAdvisorArgs validate advice query flags and return them in canonical form
+func parseAdvisorArgs(args []string) ([]string, error) {
+	var result []string
+
+	parser := &cli.App{
+		Name:        "spotinfo",
+		Flags:       advisorFlags(),
+		HideHelp:    true,
+		HideVersion: true,
+		OnUsageError: func(c *cli.Context, err error, isSubcommand bool) error {
+			return err
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() > 0 {
+				return errors.Errorf("unexpected arguments: %s", strings.Join(c.Args().Slice(), " "))
+			}
+
+			result = flagArgs(c, advisorFlags())
+
+			return nil
+		},
+	}
+
+	if err := parser.Run(append([]string{parser.Name}, args...)); err != nil {
+		return nil, errors.Wrap(err, "invalid query flags")
+	}
+
+	return result, nil
+}
+
+// flagArgs convert explicitly set flags back to command line arguments
+func flagArgs(c *cli.Context, flags []cli.Flag) []string {
+	var args []string
+
+	for _, f := range flags {
+		name := f.Names()[0]
+		if !c.IsSet(name) {
+			continue
+		}
+
+		if _, ok := f.(*cli.StringSliceFlag); ok {
+			for _, v := range c.StringSlice(name) {
+				args = append(args, fmt.Sprintf("--%s=%s", name, v))
+			}
+
+			continue
+		}
+
+		args = append(args, fmt.Sprintf("--%s=%v", name, c.Value(name)))
+	}
+
+	return args
+}
+
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get user config directory")
+	}
+
+	return filepath.Join(dir, "spotinfo"), nil
+}
+
+func loadQueries() (map[string]savedQuery, error) {
+	queries := make(map[string]savedQuery)
+
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, queriesFile))
+	if os.IsNotExist(err) {
+		return queries, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read saved queries")
+	}
+
+	if err = json.Unmarshal(bytes, &queries); err != nil {
+		return nil, errors.Wrap(err, "failed to parse saved queries")
+	}
+
+	return queries, nil
+}
+
+func storeQueries(queries map[string]savedQuery) error {
+	dir, err := configDir()
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil { //nolint:gomnd
+		return errors.Wrap(err, "failed to create config directory")
+	}
+
+	bytes, err := json.MarshalIndent(queries, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode saved queries")
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, queriesFile), bytes, 0o644); err != nil { //nolint:gosec,gomnd
+		return errors.Wrap(err, "failed to write saved queries")
+	}
+
+	return nil
+}
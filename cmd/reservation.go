@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+const (
+	defaultGuaranteedDuration  = 6 * time.Hour
+	defaultMaxInterruptionRisk = 1
+)
+
+// reservation spot and On-Demand Capacity Reservation (ODCR) cost of instance type for guaranteed duration
+type reservation struct {
+	Instance string     `json:"instance"`
+	Region   string     `json:"region"`
+	Range    spot.Range `json:"range"`
+	// USD per hour
+	SpotPrice float64 `json:"spot_price"` //nolint:tagliatelle
+	ODCRPrice float64 `json:"odcr_price"` //nolint:tagliatelle
+	// ODCR price covered by Savings Plan (--savings-plan-discount), USD per hour
+	SavingsPlanPrice float64 `json:"savings_plan_price,omitempty"` //nolint:tagliatelle
+	// probability of spot interruption during duration, percent
+	Risk float64 `json:"interruption_risk"` //nolint:tagliatelle
+	// cost for duration, USD; spot cost includes redoing work lost on interruption (half of duration on average)
+	SpotCost float64 `json:"spot_cost"` //nolint:tagliatelle
+	ODCRCost float64 `json:"odcr_cost"` //nolint:tagliatelle
+	// suggested capacity: spot or odcr
+	Suggestion string `json:"suggestion"`
+}
+
+func reservationCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "reservation",
+		Usage: "compare spot with On-Demand Capacity Reservations for workloads needing guaranteed duration",
+		Description: `Spot blocks (defined-duration spot instances) are discontinued: workloads that must run for
+a guaranteed duration need On-Demand Capacity Reservations (ODCR), billed at On-Demand price,
+optionally covered by a Savings Plan. ODCR is suggested when the probability of spot interruption
+during --duration exceeds --max-risk. On-Demand price is derived from spot price and savings, or
+taken from AWS Price List Bulk API with --price-list.
+
+   spotinfo reservation --duration=4h --type="^p3\.2xlarge$" --region=us-east-1 --savings-plan-discount=28`,
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:  "duration",
+				Usage: "guaranteed run duration (e.g. 1h, 6h)",
+				Value: defaultGuaranteedDuration,
+			},
+			&cli.Float64Flag{
+				Name:  "max-risk",
+				Usage: "maximum acceptable probability of spot interruption during duration, percent",
+				Value: defaultMaxInterruptionRisk,
+			},
+			&cli.Float64Flag{
+				Name:  "savings-plan-discount",
+				Usage: "Savings Plan discount over On-Demand price (percent) to show ODCR price covered by Savings Plan",
+			},
+			&cli.BoolFlag{
+				Name:  "price-list",
+				Usage: "use On-Demand prices from AWS Price List Bulk API (large download per region, cached for 7 days)",
+			},
+			&cli.StringFlag{
+				Name:  "os",
+				Usage: "instance operating system (windows/linux)",
+				Value: "linux",
+			},
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "set one or more AWS regions",
+				Value: cli.NewStringSlice("us-east-1"),
+			},
+			&cli.StringFlag{
+				Name:     "type",
+				Usage:    "EC2 instance type (can be RE2 regexp patten)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: table|json",
+				Value: "table",
+			},
+		},
+		Action: reservationCmd,
+	}
+}
+
+func reservationCmd(c *cli.Context) error {
+	duration, maxRisk, discount := c.Duration("duration"), c.Float64("max-risk"), c.Float64("savings-plan-discount")
+	if duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+
+	if discount < 0 || discount >= 100 {
+		return errors.Errorf("invalid Savings Plan discount %v, must be 0-100%%", discount)
+	}
+
+	var opts []spot.Option
+
+	if c.Bool("price-list") {
+		priceList, err := priceListOption(c.String("os"))
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, priceList)
+	}
+
+	advices, err := spot.GetSpotSavingsContext(c.Context, c.StringSlice("region"), c.String("type"), c.String("os"), 0, 0, 0, spot.SortByRange, false, opts...)
+	if err != nil {
+		return errors.Wrap(err, "failed to get spot savings")
+	}
+
+	reservations := make([]reservation, 0, len(advices))
+
+	for i := range advices {
+		a := &advices[i]
+		if a.Price == 0 {
+			continue
+		}
+
+		r := reservation{
+			Instance:   a.Instance,
+			Region:     a.Region,
+			Range:      a.Range,
+			SpotPrice:  a.Price,
+			Risk:       a.InterruptionProbability(duration) * 100,      //nolint:gomnd
+			SpotCost:   a.EffectiveCost(duration/2) * duration.Hours(), //nolint:gomnd
+			Suggestion: "spot",
+		}
+
+		r.ODCRPrice = a.OnDemandPrice()
+		r.ODCRCost = r.ODCRPrice * duration.Hours()
+
+		if discount > 0 {
+			r.SavingsPlanPrice = r.ODCRPrice * (1 - discount/100) //nolint:gomnd
+			r.ODCRCost = r.SavingsPlanPrice * duration.Hours()
+		}
+
+		if r.Risk > maxRisk {
+			r.Suggestion = "odcr"
+		}
+
+		reservations = append(reservations, r)
+	}
+
+	if c.String("output") == "json" {
+		printAdvicesJSON(reservations)
+
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	header := table.Row{instanceTypeColumn, regionColumn, interruptionColumn, "Spot USD/Hour", "ODCR USD/Hour"}
+	if discount > 0 {
+		header = append(header, "ODCR+SP USD/Hour")
+	}
+
+	header = append(header, "Interruption Risk", "Spot USD/Run", "ODCR USD/Run", "Suggestion")
+	t.AppendHeader(header)
+
+	for _, r := range reservations {
+		row := table.Row{r.Instance, r.Region, r.Range.Label, fmt.Sprintf("%.4f", r.SpotPrice), fmt.Sprintf("%.4f", r.ODCRPrice)}
+		if discount > 0 {
+			row = append(row, fmt.Sprintf("%.4f", r.SavingsPlanPrice))
+		}
+
+		row = append(row, fmt.Sprintf("%.2f%%", r.Risk), fmt.Sprintf("%.2f", r.SpotCost), fmt.Sprintf("%.2f", r.ODCRCost), r.Suggestion)
+		t.AppendRow(row)
+	}
+
+	t.SetStyle(tableStyle(c.String("theme")))
+	t.Render()
+
+	return nil
+}
+
+// priceListOption query option setting On-Demand prices from AWS Price List Bulk API, cached in user cache directory
+func priceListOption(instanceOS string) (spot.Option, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user cache directory")
+	}
+
+	return spot.WithEnricher(spot.OnDemandPriceList(instanceOS, filepath.Join(dir, "spotinfo"))), nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+const defaultRestartCost = 15 * time.Minute
+
+func simulateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "simulate",
+		Usage: "estimate effective cost of fleet mixes, including interruption overhead",
+		Description: `Each MIX is a comma separated list of instance types with optional counts (default 1), for example:
+
+   spotinfo simulate --region=eu-west-1 --restart-cost=45m m5.large=4 m5.large=2,c5.xlarge=2
+
+Every interruption is assumed to lose --restart-cost worth of work, which is paid again at spot price.
+The expected interruption rate is the middle of the instance interruption range (percent of instances per month).`,
+		ArgsUsage: "MIX [MIX...]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "os",
+				Usage: "instance operating system (windows/linux)",
+				Value: "linux",
+			},
+			&cli.StringFlag{
+				Name:  "region",
+				Usage: "AWS region",
+				Value: "us-east-1",
+			},
+			&cli.DurationFlag{
+				Name:  "restart-cost",
+				Usage: "work lost on each interruption (e.g. 30m, 2h)",
+				Value: defaultRestartCost,
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: table|json",
+				Value: "table",
+			},
+		},
+		Action: simulateCmd,
+	}
+}
+
+func simulateCmd(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return errors.New("at least one fleet mix is required")
+	}
+
+	simulations := make([]*spot.FleetSimulation, 0, c.NArg())
+
+	for _, arg := range c.Args().Slice() {
+		mix, err := parseFleetMix(arg)
+		if err != nil {
+			return err
+		}
+
+		s, err := spot.SimulateFleet(c.Context, c.String("region"), c.String("os"), mix, c.Duration("restart-cost"))
+		if err != nil {
+			return errors.Wrapf(err, "failed to simulate fleet %q", arg)
+		}
+
+		simulations = append(simulations, s)
+	}
+
+	if c.String("output") == "json" {
+		printAdvicesJSON(simulations)
+
+		return nil
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Fleet Mix", "Spot USD/Hour", "Overhead USD/Hour", "Effective USD/Hour", "Effective USD/Month", "Interruptions/Month"})
+
+	for _, s := range simulations {
+		t.AppendRow(table.Row{
+			formatFleetMix(s.Mix),
+			fmt.Sprintf("%.4f", s.SpotCost),
+			fmt.Sprintf("%.4f", s.Overhead),
+			fmt.Sprintf("%.4f", s.EffectiveCost),
+			fmt.Sprintf("%.2f", s.EffectiveCost*spot.HoursPerMonth),
+			fmt.Sprintf("%.1f", s.Interruptions),
+		})
+	}
+
+	t.SetStyle(tableStyle(c.String("theme")))
+	t.Style().Options.SeparateRows = true
+	t.Render()
+
+	return nil
+}
+
+// parseFleetMix parse "type[=count],type[=count]..." fleet mix
+func parseFleetMix(arg string) ([]spot.FleetMember, error) {
+	var mix []spot.FleetMember
+
+	for _, item := range strings.Split(arg, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		member := spot.FleetMember{Instance: item, Count: 1}
+
+		if i := strings.Index(item, "="); i >= 0 {
+			count, err := strconv.Atoi(item[i+1:])
+			if err != nil {
+				return nil, errors.Errorf("invalid instance count in %q", item)
+			}
+
+			member = spot.FleetMember{Instance: item[:i], Count: count}
+		}
+
+		mix = append(mix, member)
+	}
+
+	if len(mix) == 0 {
+		return nil, errors.Errorf("empty fleet mix %q", arg)
+	}
+
+	return mix, nil
+}
+
+func formatFleetMix(mix []spot.FleetMember) string {
+	items := make([]string, 0, len(mix))
+	for _, m := range mix {
+		items = append(items, fmt.Sprintf("%s=%d", m.Instance, m.Count))
+	}
+
+	return strings.Join(items, ",")
+}
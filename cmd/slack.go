@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+)
+
+// Slack Block Kit message limits
+const (
+	slackMaxBlocks  = 50
+	slackMaxAdvices = slackMaxBlocks - 4 // header, summary, divider and truncation note
+)
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+// printAdvicesSlack print advices as Slack Block Kit message: summary and one section per advice
+func printAdvicesSlack(advices []spot.Advice, opts outputOptions) {
+	mrkdwn := func(format string, args ...interface{}) slackText {
+		return slackText{Type: "mrkdwn", Text: fmt.Sprintf(format, args...)}
+	}
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: "Spot instance advice"}},
+	}
+
+	summary := fmt.Sprintf("%d instance types", len(advices))
+	if len(advices) > 0 {
+		best := advices[0]
+		for _, advice := range advices[1:] {
+			if advice.Savings > best.Savings {
+				best = advice
+			}
+		}
+
+		summary += fmt.Sprintf(", best savings *%d%%* on `%s` in %s", best.Savings, best.Instance, best.Region)
+	}
+
+	summaryText := slackText{Type: "mrkdwn", Text: summary}
+	blocks = append(blocks, slackBlock{Type: "section", Text: &summaryText}, slackBlock{Type: "divider"})
+
+	for i, advice := range advices {
+		if i == slackMaxAdvices {
+			blocks = append(blocks, slackBlock{
+				Type:     "context",
+				Elements: []slackText{mrkdwn("... and %d more, use --output=json for full results", len(advices)-i)},
+			})
+
+			break
+		}
+
+		title := fmt.Sprintf("*%s*", advice.Instance)
+		if opts.region {
+			title += " in " + advice.Region
+		}
+
+		fields := []slackText{
+			mrkdwn("*vCPU/Memory*\n%d / %vGiB", advice.Info.Cores, advice.Info.RAM),
+			mrkdwn("*Savings*\n%d%%", advice.Savings),
+			mrkdwn("*Interruption*\n%s", advice.Range.Label),
+			mrkdwn("*USD/Hour*\n%v", advice.Price),
+		}
+
+		if opts.monthly {
+			fields = append(fields, mrkdwn("*Savings USD/Month*\n%.2f", advice.MonthlySavings()))
+		}
+
+		if opts.compliance && len(advice.Compliance) > 0 {
+			fields = append(fields, mrkdwn("*Compliance*\n%s", strings.Join(advice.Compliance, ", ")))
+		}
+
+		if len(advice.Annotations) > 0 {
+			fields = append(fields, mrkdwn("*Annotations*\n%s", formatAnnotations(advice.Annotations)))
+		}
+
+		titleText := slackText{Type: "mrkdwn", Text: title}
+		blocks = append(blocks, slackBlock{Type: "section", Text: &titleText, Fields: fields})
+	}
+
+	printAdvicesJSON(struct {
+		Blocks []slackBlock `json:"blocks"`
+	}{Blocks: blocks})
+}
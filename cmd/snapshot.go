@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+const (
+	snapshotsDir      = "snapshots"
+	snapshotIDFormat  = "20060102T150405.000000Z"
+	latestSnapshotID  = "latest"
+	snapshotExtension = ".json"
+)
+
+// snapshot archived query results
+type snapshot struct {
+	ID      string        `json:"id"`
+	Created time.Time     `json:"created"`
+	Args    []string      `json:"args"` // effective query flags, including saved query and workspace config ones
+	Advices []spot.Advice `json:"advices"`
+}
+
+func snapshotsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "snapshots",
+		Usage: "list, show and compare results saved with --snapshot",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list saved snapshots",
+				Action: listSnapshotsCmd,
+			},
+			{
+				Name:      "show",
+				Usage:     "show snapshot results",
+				ArgsUsage: "ID|latest",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "format output: number|text|json|table|csv|tsv|plain|slack",
+						Value: "table",
+					},
+				},
+				Action: showSnapshotCmd,
+			},
+			{
+				Name:      "diff",
+				Usage:     "compare results of two snapshots",
+				ArgsUsage: "OLD_ID NEW_ID|latest",
+				Action:    diffSnapshotsCmd,
+			},
+		},
+	}
+}
+
+func snapshotsPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, snapshotsDir), nil
+}
+
+func saveSnapshot(advices []spot.Advice, args []string) (string, error) {
+	dir, err := snapshotsPath()
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil { //nolint:gomnd
+		return "", errors.Wrap(err, "failed to create snapshots directory")
+	}
+
+	now := time.Now().UTC()
+	s := snapshot{ID: now.Format(snapshotIDFormat), Created: now, Args: args, Advices: advices}
+
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode snapshot")
+	}
+
+	// never overwrite existing snapshot
+	f, err := os.OpenFile(filepath.Join(dir, s.ID+snapshotExtension), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:gosec,gomnd
+	if os.IsExist(err) {
+		return "", errors.Errorf("snapshot %s already exists", s.ID)
+	} else if err != nil {
+		return "", errors.Wrap(err, "failed to create snapshot")
+	}
+
+	if _, err = f.Write(bytes); err != nil {
+		f.Close() //nolint:errcheck,gosec
+
+		return "", errors.Wrap(err, "failed to write snapshot")
+	}
+
+	if err = f.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to write snapshot")
+	}
+
+	return s.ID, nil
+}
+
+// snapshotIDs sorted (oldest first) snapshot IDs
+func snapshotIDs() ([]string, error) {
+	dir, err := snapshotsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to list snapshots")
+	}
+
+	var ids []string
+
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), snapshotExtension) {
+			ids = append(ids, strings.TrimSuffix(f.Name(), snapshotExtension))
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+func loadSnapshot(id string) (*snapshot, error) {
+	if id == latestSnapshotID {
+		ids, err := snapshotIDs()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ids) == 0 {
+			return nil, errors.New("no snapshots saved")
+		}
+
+		id = ids[len(ids)-1]
+	}
+
+	dir, err := snapshotsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, filepath.Base(id)+snapshotExtension))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read snapshot %s", id)
+	}
+
+	var s snapshot
+	if err = json.Unmarshal(bytes, &s); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse snapshot %s", id)
+	}
+
+	return &s, nil
+}
+
+func listSnapshotsCmd(c *cli.Context) error {
+	ids, err := snapshotIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		s, err := loadSnapshot(id)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s  %s  %d results  %s\n", s.ID, displayTime(s.Created), len(s.Advices), strings.Join(s.Args, " "))
+	}
+
+	return nil
+}
+
+func showSnapshotCmd(c *cli.Context) error {
+	s, err := loadSnapshot(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	regions := make(map[string]bool)
+	for _, advice := range s.Advices {
+		regions[advice.Region] = true
+	}
+
+	printAdvices(s.Advices, c.String("output"), outputOptions{region: len(regions) > 1, precision: defaultPricePrecision})
+
+	return nil
+}
+
+func diffSnapshotsCmd(c *cli.Context) error {
+	if c.NArg() != 2 { //nolint:gomnd
+		return errors.New("two snapshot IDs are required")
+	}
+
+	older, err := loadSnapshot(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	newer, err := loadSnapshot(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	for _, line := range diffAdvices(older.Advices, newer.Advices) {
+		fmt.Println(line)
+	}
+
+	return nil
+}
+
+// diffAdvices compare advices by region and instance type: added (+), removed (-) and changed (~)
+func diffAdvices(older, newer []spot.Advice) []string {
+	key := func(a *spot.Advice) string { return a.Region + "/" + a.Instance }
+
+	old := make(map[string]*spot.Advice, len(older))
+	for i := range older {
+		old[key(&older[i])] = &older[i]
+	}
+
+	var added, removed, changed []string
+
+	seen := make(map[string]bool, len(newer))
+
+	for i := range newer {
+		n := &newer[i]
+		k := key(n)
+		seen[k] = true
+
+		o, ok := old[k]
+		if !ok {
+			added = append(added, fmt.Sprintf("+ %s: savings=%d%%, interruption='%s', price=%v", k, n.Savings, n.Range.Label, n.Price))
+
+			continue
+		}
+
+		var changes []string
+
+		if o.Savings != n.Savings {
+			changes = append(changes, fmt.Sprintf("savings %d%% -> %d%%", o.Savings, n.Savings))
+		}
+
+		if o.Range.Label != n.Range.Label {
+			changes = append(changes, fmt.Sprintf("interruption '%s' -> '%s'", o.Range.Label, n.Range.Label))
+		}
+
+		if o.Price != n.Price {
+			changes = append(changes, fmt.Sprintf("price %v -> %v", o.Price, n.Price))
+		}
+
+		if len(changes) > 0 {
+			changed = append(changed, fmt.Sprintf("~ %s: %s", k, strings.Join(changes, ", ")))
+		}
+	}
+
+	for i := range older {
+		if k := key(&older[i]); !seen[k] {
+			removed = append(removed, "- "+k)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+
+	return append(append(removed, added...), changed...)
+}
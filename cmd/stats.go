@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+func statsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "stats",
+		Usage: "summarize savings and interruption frequency distribution per region",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "os",
+				Usage: "instance operating system (windows/linux)",
+				Value: "linux",
+			},
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "set one or more AWS regions",
+				Value: cli.NewStringSlice("all"),
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "EC2 instance type (can be RE2 regexp patten)",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: table|json",
+				Value: "table",
+			},
+		},
+		Action: statsCmd,
+	}
+}
+
+func statsCmd(c *cli.Context) error {
+	advices, err := spot.GetSpotSavingsContext(c.Context, c.StringSlice("region"), c.String("type"), c.String("os"), 0, 0, 0, spot.SortByRegion, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get spot savings")
+	}
+
+	stats := spot.Stats(advices)
+
+	if c.String("output") == "json" {
+		printAdvicesJSON(stats)
+
+		return nil
+	}
+
+	// interruption ranges present in any region, from least to most frequent
+	ranges := make(map[spot.Range]bool)
+
+	for _, s := range stats {
+		for _, b := range s.Bands {
+			ranges[b.Range] = true
+		}
+	}
+
+	columns := make([]spot.Range, 0, len(ranges))
+	for r := range ranges {
+		columns = append(columns, r)
+	}
+
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Max < columns[j].Max })
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	header := table.Row{regionColumn, "Instances", "Mean Savings", "Median Savings"}
+	for _, r := range columns {
+		header = append(header, "Interruption "+r.Label)
+	}
+
+	t.AppendHeader(header)
+
+	for _, s := range stats {
+		shares := make(map[spot.Range]float64, len(s.Bands))
+		for _, b := range s.Bands {
+			shares[b.Range] = b.Share
+		}
+
+		row := table.Row{s.Region, s.Instances, fmt.Sprintf("%.1f%%", s.MeanSavings), fmt.Sprintf("%.1f%%", s.MedianSavings)}
+		for _, r := range columns {
+			row = append(row, fmt.Sprintf("%.0f%%", shares[r]*100)) //nolint:gomnd
+		}
+
+		t.AppendRow(row)
+	}
+
+	configs := make([]table.ColumnConfig, 0, len(header)-1)
+	for i := 2; i <= len(header); i++ {
+		configs = append(configs, table.ColumnConfig{Number: i, Align: text.AlignRight})
+	}
+
+	t.SetColumnConfigs(configs)
+	t.SetStyle(tableStyle(c.String("theme")))
+	t.Render()
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"regexp"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+// tfPlan subset of Terraform JSON plan (terraform show -json plan.out)
+type tfPlan struct {
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Type    string `json:"type"`
+		Change  struct {
+			Actions []string               `json:"actions"`
+			After   map[string]interface{} `json:"after"`
+		} `json:"change"`
+	} `json:"resource_changes"` //nolint:tagliatelle
+}
+
+// tfInstance on-demand instance type used by Terraform resource
+type tfInstance struct {
+	Resource    string       `json:"resource"`
+	Instance    string       `json:"instance"`
+	Spot        *spot.Advice `json:"spot"`        // same instance type as spot
+	Alternative *spot.Advice `json:"alternative"` // best spot instance type with at least the same resources
+}
+
+func analyzeTerraformCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "analyze-tf",
+		Usage: "find on-demand instances in Terraform plan and report spot alternatives and savings",
+		Description: `Analyzes aws_instance, aws_launch_template and aws_autoscaling_group resources of a JSON plan;
+estimated savings are per instance (resource instance counts are not known from plan):
+
+   terraform plan -out=plan.out && terraform show -json plan.out > plan.json
+   spotinfo analyze-tf --region=eu-west-1 plan.json`,
+		ArgsUsage: "PLAN_JSON",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "region",
+				Usage: "AWS region of planned resources",
+				Value: "us-east-1",
+			},
+			&cli.StringFlag{
+				Name:  "os",
+				Usage: "instance operating system (windows/linux)",
+				Value: "linux",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: table|json",
+				Value: "table",
+			},
+			&cli.BoolFlag{
+				Name:  "price-list",
+				Usage: "use On-Demand prices from AWS Price List Bulk API for monthly savings (large download per region, cached for 7 days)",
+			},
+			&cli.StringFlag{
+				Name:  "theme",
+				Usage: "table theme: light|dark|ascii (default: box drawing on terminal, ascii when piped)",
+			},
+		},
+		Action: analyzeTerraformCmd,
+	}
+}
+
+func analyzeTerraformCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("Terraform JSON plan file is required")
+	}
+
+	bytes, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return errors.Wrap(err, "failed to read Terraform plan")
+	}
+
+	var plan tfPlan
+	if err = json.Unmarshal(bytes, &plan); err != nil {
+		return errors.Wrap(err, "failed to parse Terraform JSON plan")
+	}
+
+	var results []tfInstance
+
+	for _, planned := range plannedOnDemandInstances(&plan) {
+		r, err := analyzeInstance(c, planned.Resource, planned.Instance)
+		if err != nil {
+			return err
+		}
+
+		results = append(results, *r)
+	}
+
+	if c.String("output") == "json" {
+		if results == nil {
+			results = []tfInstance{}
+		}
+
+		printAdvicesJSON(results)
+
+		return nil
+	}
+
+	theme := commandTheme(c)
+	if err = validateTheme(theme); err != nil {
+		return err
+	}
+
+	printTerraformAnalysis(results, theme)
+
+	return nil
+}
+
+// plannedOnDemandInstances on-demand instance types of created, updated or replaced resources
+func plannedOnDemandInstances(plan *tfPlan) []tfInstance {
+	var instances []tfInstance
+
+	for _, rc := range plan.ResourceChanges {
+		if isDelete(rc.Change.Actions) || rc.Change.After == nil {
+			continue
+		}
+
+		for _, instance := range onDemandInstanceTypes(rc.Type, rc.Change.After) {
+			instances = append(instances, tfInstance{Resource: rc.Address, Instance: instance})
+		}
+	}
+
+	return instances
+}
+
+func isDelete(actions []string) bool {
+	return len(actions) == 1 && actions[0] == "delete"
+}
+
+func analyzeInstance(c *cli.Context, resource, instance string) (*tfInstance, error) {
+	region, instanceOS := c.String("region"), c.String("os")
+	result := tfInstance{Resource: resource, Instance: instance}
+
+	var opts []spot.Option
+
+	if c.Bool("price-list") {
+		priceList, err := priceListOption(instanceOS)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, priceList)
+	}
+
+	advices, err := spot.GetSpotSavingsContext(c.Context, []string{region}, "^"+regexp.QuoteMeta(instance)+"$", instanceOS, 0, 0, 0, spot.SortByRange, false, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get spot savings for %s", instance)
+	}
+
+	if len(advices) == 0 {
+		return &result, nil
+	}
+
+	result.Spot = &advices[0]
+
+	// best spot instance type with at least the same vCPU and memory; fractional memory (e.g. 0.5 GiB) is rounded up
+	info := result.Spot.Info
+
+	alternatives, err := spot.GetSpotSavingsContext(c.Context, []string{region}, "", instanceOS, info.Cores, int(math.Ceil(float64(info.RAM))), 0, spot.SortByAdjustedPrice, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get spot alternatives for %s", instance)
+	}
+
+	for i := range alternatives {
+		if alternatives[i].Price > 0 && alternatives[i].Instance != instance {
+			result.Alternative = &alternatives[i]
+
+			break
+		}
+	}
+
+	return &result, nil
+}
+
+// onDemandInstanceTypes instance types of planned resource, unless it runs on spot already
+func onDemandInstanceTypes(resourceType string, after map[string]interface{}) []string {
+	switch resourceType {
+	case "aws_instance", "aws_launch_template":
+		if marketType(after) == "spot" {
+			return nil
+		}
+
+		if t, ok := after["instance_type"].(string); ok && t != "" {
+			return []string{t}
+		}
+	case "aws_autoscaling_group":
+		// instance types of mixed instances policy with on-demand capacity
+		policy := first(after["mixed_instances_policy"])
+		if policy == nil {
+			return nil
+		}
+
+		if distribution := first(policy["instances_distribution"]); distribution != nil {
+			if p, ok := distribution["on_demand_percentage_above_base_capacity"].(float64); ok && p == 0 {
+				if base, ok := distribution["on_demand_base_capacity"].(float64); !ok || base == 0 {
+					return nil
+				}
+			}
+		}
+
+		var types []string
+
+		if lt := first(policy["launch_template"]); lt != nil {
+			if overrides, ok := lt["override"].([]interface{}); ok {
+				for _, o := range overrides {
+					if m, ok := o.(map[string]interface{}); ok {
+						if t, ok := m["instance_type"].(string); ok && t != "" {
+							types = append(types, t)
+						}
+					}
+				}
+			}
+		}
+
+		return types
+	}
+
+	return nil
+}
+
+// marketType market type of instance market options, if any
+func marketType(after map[string]interface{}) string {
+	if options := first(after["instance_market_options"]); options != nil {
+		if t, ok := options["market_type"].(string); ok {
+			return t
+		}
+	}
+
+	return ""
+}
+
+// first first element of Terraform nested block list
+func first(block interface{}) map[string]interface{} {
+	if list, ok := block.([]interface{}); ok && len(list) > 0 {
+		m, _ := list[0].(map[string]interface{})
+
+		return m
+	}
+
+	return nil
+}
+
+func printTerraformAnalysis(results []tfInstance, theme string) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Resource", instanceTypeColumn, savingsColumn, interruptionColumn, priceColumn, monthlyColumn, "Spot Alternative"})
+
+	var total float64
+
+	for _, r := range results {
+		if r.Spot == nil {
+			t.AppendRow(table.Row{r.Resource, r.Instance, "no spot data"})
+
+			continue
+		}
+
+		monthly := r.Spot.MonthlySavings()
+		total += monthly
+
+		alternative := ""
+		if a := r.Alternative; a != nil {
+			alternative = fmt.Sprintf("%s (%d vCPU, %vGiB, %s, %v USD/hour)", a.Instance, a.Info.Cores, a.Info.RAM, a.Range.Label, a.Price)
+		}
+
+		t.AppendRow(table.Row{r.Resource, r.Instance, r.Spot.Savings, r.Spot.Range.Label, r.Spot.Price, fmt.Sprintf("%.2f", monthly), alternative})
+	}
+
+	t.AppendFooter(table.Row{"Total", "", "", "", "", fmt.Sprintf("%.2f", total), ""})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Name: savingsColumn, Transformer: text.NewNumberTransformer("%d%%")},
+		{Name: monthlyColumn, Align: text.AlignRight, AlignFooter: text.AlignRight},
+	})
+	t.SetStyle(tableStyle(theme))
+	t.Render()
+}
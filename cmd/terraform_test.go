@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func Test_plannedOnDemandInstances(t *testing.T) {
+	tests := []struct {
+		name string
+		plan string
+		want []tfInstance
+	}{
+		{
+			name: "on-demand aws_instance",
+			plan: `{"resource_changes": [{"address": "aws_instance.web", "type": "aws_instance",
+				"change": {"actions": ["create"], "after": {"instance_type": "m5.large"}}}]}`,
+			want: []tfInstance{{Resource: "aws_instance.web", Instance: "m5.large"}},
+		},
+		{
+			name: "spot aws_instance is skipped",
+			plan: `{"resource_changes": [{"address": "aws_instance.web", "type": "aws_instance",
+				"change": {"actions": ["create"], "after": {"instance_type": "m5.large",
+				"instance_market_options": [{"market_type": "spot"}]}}}]}`,
+		},
+		{
+			name: "spot aws_launch_template is skipped",
+			plan: `{"resource_changes": [{"address": "aws_launch_template.lt", "type": "aws_launch_template",
+				"change": {"actions": ["update"], "after": {"instance_type": "c5.xlarge",
+				"instance_market_options": [{"market_type": "spot"}]}}}]}`,
+		},
+		{
+			name: "aws_launch_template without instance type",
+			plan: `{"resource_changes": [{"address": "aws_launch_template.lt", "type": "aws_launch_template",
+				"change": {"actions": ["create"], "after": {"instance_type": null}}}]}`,
+		},
+		{
+			name: "spot only asg is skipped",
+			plan: `{"resource_changes": [{"address": "aws_autoscaling_group.asg", "type": "aws_autoscaling_group",
+				"change": {"actions": ["create"], "after": {"mixed_instances_policy": [{
+				"instances_distribution": [{"on_demand_base_capacity": 0, "on_demand_percentage_above_base_capacity": 0}],
+				"launch_template": [{"override": [{"instance_type": "m5.large"}]}]}]}}}]}`,
+		},
+		{
+			name: "asg with on-demand base capacity",
+			plan: `{"resource_changes": [{"address": "aws_autoscaling_group.asg", "type": "aws_autoscaling_group",
+				"change": {"actions": ["create"], "after": {"mixed_instances_policy": [{
+				"instances_distribution": [{"on_demand_base_capacity": 2, "on_demand_percentage_above_base_capacity": 0}],
+				"launch_template": [{"override": [{"instance_type": "m5.large"}]}]}]}}}]}`,
+			want: []tfInstance{{Resource: "aws_autoscaling_group.asg", Instance: "m5.large"}},
+		},
+		{
+			name: "asg launch template overrides",
+			plan: `{"resource_changes": [{"address": "aws_autoscaling_group.asg", "type": "aws_autoscaling_group",
+				"change": {"actions": ["update"], "after": {"mixed_instances_policy": [{
+				"instances_distribution": [{"on_demand_percentage_above_base_capacity": 50}],
+				"launch_template": [{"override": [{"instance_type": "m5.large"}, {"weighted_capacity": "2"}, {"instance_type": "m5a.large"}]}]}]}}}]}`,
+			want: []tfInstance{
+				{Resource: "aws_autoscaling_group.asg", Instance: "m5.large"},
+				{Resource: "aws_autoscaling_group.asg", Instance: "m5a.large"},
+			},
+		},
+		{
+			name: "asg without mixed instances policy",
+			plan: `{"resource_changes": [{"address": "aws_autoscaling_group.asg", "type": "aws_autoscaling_group",
+				"change": {"actions": ["create"], "after": {"launch_template": [{"id": "lt-1"}]}}}]}`,
+		},
+		{
+			name: "deleted resource is skipped",
+			plan: `{"resource_changes": [{"address": "aws_instance.old", "type": "aws_instance",
+				"change": {"actions": ["delete"], "after": null}}]}`,
+		},
+		{
+			name: "replaced resource",
+			plan: `{"resource_changes": [
+				{"address": "aws_instance.a", "type": "aws_instance", "change": {"actions": ["delete", "create"], "after": {"instance_type": "t3.large"}}},
+				{"address": "aws_instance.b", "type": "aws_instance", "change": {"actions": ["create", "delete"], "after": {"instance_type": "t3.small"}}}]}`,
+			want: []tfInstance{{Resource: "aws_instance.a", Instance: "t3.large"}, {Resource: "aws_instance.b", Instance: "t3.small"}},
+		},
+		{
+			name: "other resource types are ignored",
+			plan: `{"resource_changes": [{"address": "aws_s3_bucket.b", "type": "aws_s3_bucket",
+				"change": {"actions": ["create"], "after": {"instance_type": "m5.large"}}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var plan tfPlan
+			if err := json.Unmarshal([]byte(tt.plan), &plan); err != nil {
+				t.Fatalf("invalid plan fixture: %v", err)
+			}
+
+			if got := plannedOnDemandInstances(&plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plannedOnDemandInstances() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isDelete(t *testing.T) {
+	tests := []struct {
+		actions []string
+		want    bool
+	}{
+		{actions: []string{"delete"}, want: true},
+		{actions: []string{"delete", "create"}, want: false},
+		{actions: []string{"create", "delete"}, want: false},
+		{actions: []string{"update"}, want: false},
+		{actions: []string{"no-op"}, want: false},
+		{actions: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isDelete(tt.actions); got != tt.want {
+			t.Errorf("isDelete(%v) = %v, want %v", tt.actions, got, tt.want)
+		}
+	}
+}
+
+func Test_marketType(t *testing.T) {
+	tests := []struct {
+		name  string
+		after string
+		want  string
+	}{
+		{name: "spot", after: `{"instance_market_options": [{"market_type": "spot"}]}`, want: "spot"},
+		{name: "empty block list", after: `{"instance_market_options": []}`, want: ""},
+		{name: "no market options", after: `{"instance_type": "m5.large"}`, want: ""},
+		{name: "null market type", after: `{"instance_market_options": [{"market_type": null}]}`, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var after map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.after), &after); err != nil {
+				t.Fatalf("invalid fixture: %v", err)
+			}
+
+			if got := marketType(after); got != tt.want {
+				t.Errorf("marketType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commandTheme(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "not set", args: []string{"analyze-tf"}, want: ""},
+		{name: "after command name", args: []string{"analyze-tf", "--theme", "ascii"}, want: "ascii"},
+		{name: "before command name", args: []string{"--theme", "dark", "analyze-tf"}, want: "dark"},
+		{name: "command flag wins", args: []string{"--theme", "dark", "analyze-tf", "--theme", "light"}, want: "light"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			cmd := analyzeTerraformCommand()
+			cmd.Action = func(c *cli.Context) error {
+				got = commandTheme(c)
+
+				return nil
+			}
+
+			app := &cli.App{Flags: advisorFlags(), Commands: []*cli.Command{cmd}}
+			if err := app.Run(append([]string{"spotinfo"}, tt.args...)); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("commandTheme() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// table themes
+const (
+	themeLight = "light"
+	themeDark  = "dark"
+	themeASCII = "ascii"
+)
+
+// setColors disable colored output when asked to, when NO_COLOR is set, or when stdout is not a terminal
+func setColors(noColor bool) {
+	if noColor || os.Getenv("NO_COLOR") != "" || !isTerminal(os.Stdout) {
+		text.DisableColors()
+	}
+}
+
+func validateTheme(theme string) error {
+	switch theme {
+	case "", themeLight, themeDark, themeASCII:
+		return nil
+	default:
+		return errors.Errorf("unsupported theme %q, use %s|%s|%s", theme, themeLight, themeDark, themeASCII)
+	}
+}
+
+// commandTheme table theme of command with own --theme flag: set after command name, or before it
+func commandTheme(c *cli.Context) string {
+	for _, ctx := range c.Lineage() {
+		if localFlags(ctx)["theme"] {
+			return ctx.String("theme")
+		}
+	}
+
+	return ""
+}
+
+// tableStyle table style for theme; without theme: box drawing on terminal and plain ASCII when piped
+func tableStyle(theme string) table.Style {
+	switch theme {
+	case themeLight:
+		return table.StyleColoredBright
+	case themeDark:
+		return table.StyleColoredDark
+	case themeASCII:
+		return table.StyleDefault
+	}
+
+	if isTerminal(os.Stdout) {
+		return table.StyleLight
+	}
+
+	return table.StyleDefault
+}
+
+// plainStyle aligned fixed-width columns without borders, separators or colors, for awk/cut processing
+func plainStyle() table.Style {
+	style := table.StyleDefault
+	style.Name = "plain"
+	style.Options = table.OptionsNoBordersAndSeparators
+	style.Options.SeparateColumns = true
+	style.Box.MiddleVertical = "  "
+	style.Box.PaddingLeft = ""
+	style.Box.PaddingRight = ""
+
+	return style
+}
@@ -0,0 +1,30 @@
+package main
+
+import "time"
+
+// layout of timestamps in table and text output; JSON uses RFC3339
+const displayTimeLayout = "2006-01-02 15:04:05 MST"
+
+// show timestamps in UTC instead of local time (--utc)
+var utcTimes bool
+
+// displayTime format timestamp for humans: local time, or UTC with --utc
+func displayTime(t time.Time) string {
+	if utcTimes {
+		return t.UTC().Format(displayTimeLayout)
+	}
+
+	return t.Local().Format(displayTimeLayout)
+}
+
+// displayDate format RFC3339 or ISO 8601 basic zone offset timestamp (e.g. build or embedded data date) for humans;
+// other values are returned as is
+func displayDate(value string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05-0700"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return displayTime(t)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+const (
+	latestReleaseURL = "https://api.github.com/repos/alexei-led/spotinfo/releases/latest"
+	// disable new release check when set
+	noUpdateCheckEnv    = "SPOTINFO_NO_UPDATE_CHECK"
+	updateCheckFile     = "update-check"
+	updateCheckInterval = 24 * time.Hour
+	updateCheckTimeout  = 3 * time.Second
+	// how long to wait for pending update check before exit
+	updateNoticeWait = 300 * time.Millisecond
+)
+
+// pending update check notice
+var updateNoticeCh <-chan string
+
+type release struct {
+	TagName     string    `json:"tag_name"`     //nolint:tagliatelle
+	PublishedAt time.Time `json:"published_at"` //nolint:tagliatelle
+}
+
+// checkForUpdate check (at most once a day) for a newer release in background; channel gets notice, if any
+func checkForUpdate(ctx context.Context) <-chan string {
+	notice := make(chan string, 1)
+
+	go func() {
+		defer close(notice)
+
+		path, due := updateCheckDue()
+		if !due {
+			return
+		}
+
+		r, err := latestRelease(ctx)
+		if err != nil {
+			return
+		}
+
+		recordUpdateCheck(path)
+
+		if msg := updateNotice(r, Version, spot.EmbeddedDataDate); msg != "" {
+			notice <- msg
+		}
+	}()
+
+	return notice
+}
+
+// updateCheckDue check if last successful update check is older than check interval; returns check timestamp file
+func updateCheckDue() (string, bool) {
+	dir, err := configDir()
+	if err != nil {
+		return "", false
+	}
+
+	path := filepath.Join(dir, updateCheckFile)
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < updateCheckInterval {
+		return "", false
+	}
+
+	return path, true
+}
+
+// recordUpdateCheck record successful update check, so the next one is made after check interval
+func recordUpdateCheck(path string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gomnd
+		return
+	}
+
+	_ = ioutil.WriteFile(path, []byte(time.Now().UTC().Format(time.RFC3339)), 0o644) //nolint:gosec,gomnd
+}
+
+func latestRelease(ctx context.Context) (*release, error) {
+	ctx, cancel := context.WithTimeout(ctx, updateCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	var r release
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &r, nil
+}
+
+// updateNotice one-line notice about newer release or newer embedded data; empty if up to date
+func updateNotice(r *release, version, dataDate string) string {
+	latest, current := strings.TrimPrefix(r.TagName, "v"), strings.TrimPrefix(version, "v")
+
+	if version != "dev" && newerVersion(latest, current) {
+		return fmt.Sprintf("spotinfo %s is available (current %s): https://github.com/alexei-led/spotinfo/releases", latest, version)
+	}
+
+	if date, err := time.Parse(time.RFC3339, dataDate); err == nil && r.PublishedAt.After(date) && current != latest {
+		return fmt.Sprintf("spotinfo %s (%s) embeds newer data than this build (%s)", latest, r.PublishedAt.Format("2006-01-02"), date.Format("2006-01-02"))
+	}
+
+	return ""
+}
+
+// newerVersion compare dotted numeric versions: true if a > b
+func newerVersion(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+
+		if x != y {
+			return x > y
+		}
+	}
+
+	return false
+}
+
+// startUpdateCheck start background update check, unless disabled or running offline (replay, dry run)
+func startUpdateCheck(c *cli.Context) error {
+	if c.Bool("no-update-check") || c.Bool("dry-run") || os.Getenv(noUpdateCheckEnv) != "" || os.Getenv(spot.ReplayEnv) != "" {
+		return nil
+	}
+
+	updateNoticeCh = checkForUpdate(mainCtx)
+
+	return nil
+}
+
+// printUpdateNotice print update notice to stderr, if update check completes in time
+func printUpdateNotice(c *cli.Context) error {
+	if updateNoticeCh == nil {
+		return nil
+	}
+
+	select {
+	case msg := <-updateNoticeCh:
+		if msg != "" {
+			fmt.Fprintln(os.Stderr, msg)
+		}
+	case <-time.After(updateNoticeWait):
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_newerVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "1.10", b: "1.9", want: true},
+		{a: "1.9", b: "1.10", want: false},
+		{a: "1.2.1", b: "1.2", want: true},
+		{a: "1.2", b: "1.2.0", want: false},
+		{a: "2.0.0", b: "1.99.99", want: true},
+		{a: "1.3.0", b: "1.3.0", want: false},
+		{a: "1.3.0", b: "dev", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := newerVersion(tt.a, tt.b); got != tt.want {
+			t.Errorf("newerVersion(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func Test_updateNotice(t *testing.T) {
+	published := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		tag      string
+		version  string
+		dataDate string
+		want     string
+	}{
+		{
+			name:    "newer release",
+			tag:     "v1.10.0",
+			version: "1.9.0",
+			want:    "spotinfo 1.10.0 is available (current 1.9.0): https://github.com/alexei-led/spotinfo/releases",
+		},
+		{
+			name:    "newer release, v prefixed version",
+			tag:     "v1.10.0",
+			version: "v1.9.0",
+			want:    "spotinfo 1.10.0 is available (current v1.9.0): https://github.com/alexei-led/spotinfo/releases",
+		},
+		{
+			name:     "same release, v prefixed version",
+			tag:      "v1.9.0",
+			version:  "v1.9.0",
+			dataDate: "2021-05-01T00:00:00Z",
+		},
+		{
+			name:     "older release",
+			tag:      "v1.8.0",
+			version:  "1.9.0",
+			dataDate: "2021-07-01T00:00:00Z",
+		},
+		{
+			name:     "dev build with older data",
+			tag:      "v1.9.0",
+			version:  "dev",
+			dataDate: "2021-05-01T00:00:00Z",
+			want:     "spotinfo 1.9.0 (2021-06-01) embeds newer data than this build (2021-05-01)",
+		},
+		{
+			name:     "dev build with newer data",
+			tag:      "v1.9.0",
+			version:  "dev",
+			dataDate: "2021-07-01T00:00:00Z",
+		},
+		{
+			name:     "unknown data date",
+			tag:      "v1.9.0",
+			version:  "dev",
+			dataDate: "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &release{TagName: tt.tag, PublishedAt: published}
+			if got := updateNotice(r, tt.version, tt.dataDate); got != tt.want {
+				t.Errorf("updateNotice() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
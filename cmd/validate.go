@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2" //nolint:gci
+)
+
+// fleet config validation issues
+const (
+	issueNotSpotEligible    = "not_spot_eligible"
+	issuePreviousGeneration = "previous_generation"
+)
+
+// fleet config fields with instance types and availability zones
+const (
+	instanceTypeKey     = "InstanceType"
+	availabilityZoneKey = "AvailabilityZone"
+)
+
+// fleetIssue problem of instance type in fleet config
+type fleetIssue struct {
+	Region   string `json:"region,omitempty"` // empty for issues not specific to region
+	Instance string `json:"instance"`
+	Issue    string `json:"issue"`
+	Reason   string `json:"reason"`
+}
+
+// fleetValidation fleet config validation result
+type fleetValidation struct {
+	Regions   []string     `json:"regions"`
+	Instances []string     `json:"instances"`
+	Issues    []fleetIssue `json:"issues"`
+}
+
+func validateFleetCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "validate-fleet",
+		Usage: "statically validate instance types of Spot Fleet, EC2 Fleet or Auto Scaling group config against spot advisor data",
+		Description: `Instance types are taken from all "InstanceType" fields of the JSON config (launch specifications,
+   launch template overrides, mixed instances policy overrides) and checked to be available as spot instances in the
+   target regions and not of previous generation. No AWS API is called. Target regions are --region, or derived from
+   "AvailabilityZone" fields of the config.`,
+		ArgsUsage: "FILE",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "region",
+				Usage: "target AWS regions (default: regions of config availability zones, or us-east-1)",
+			},
+			&cli.StringFlag{
+				Name:  "os",
+				Usage: "instance operating system (windows/linux)",
+				Value: "linux",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "format output: text|json",
+				Value: "text",
+			},
+		},
+		Action: validateFleetCmd,
+	}
+}
+
+func validateFleetCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("fleet config file is required")
+	}
+
+	bytes, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return errors.Wrap(err, "failed to read fleet config")
+	}
+
+	var config interface{}
+	if err = json.Unmarshal(bytes, &config); err != nil {
+		return errors.Wrap(err, "failed to parse fleet config")
+	}
+
+	fields := make(map[string]map[string]bool)
+	collectFields(config, fields)
+
+	instances := sortedKeys(fields[instanceTypeKey])
+	if len(instances) == 0 {
+		return errors.Errorf("no %s found in fleet config", instanceTypeKey)
+	}
+
+	regions := c.StringSlice("region")
+	if len(regions) == 0 {
+		regions = zoneRegions(fields[availabilityZoneKey])
+	}
+
+	result, err := validateFleet(c.Context, regions, instances, c.String("os"))
+	if err != nil {
+		return err
+	}
+
+	if c.String("output") == "json" {
+		printAdvicesJSON(result)
+	} else {
+		for _, issue := range result.Issues {
+			if issue.Region != "" {
+				fmt.Printf("%s ", issue.Region)
+			}
+
+			fmt.Printf("%s: %s (%s)\n", issue.Instance, issue.Reason, issue.Issue)
+		}
+
+		fmt.Printf("%d instance types in %s, %d issues (availability zones are not checked: no per zone spot data)\n",
+			len(result.Instances), strings.Join(result.Regions, ", "), len(result.Issues))
+	}
+
+	if len(result.Issues) > 0 {
+		return errors.Errorf("fleet config has %d issues", len(result.Issues))
+	}
+
+	return nil
+}
+
+func validateFleet(ctx context.Context, regions, instances []string, instanceOS string) (*fleetValidation, error) {
+	quoted := make([]string, len(instances))
+	for i, instance := range instances {
+		quoted[i] = regexp.QuoteMeta(instance)
+	}
+
+	pattern := "^(" + strings.Join(quoted, "|") + ")$"
+	result := fleetValidation{Regions: regions, Instances: instances, Issues: []fleetIssue{}}
+
+	// instance generation does not depend on region
+	for _, instance := range instances {
+		if spot.InstanceGeneration(instance) == spot.GenerationPrevious {
+			result.Issues = append(result.Issues, fleetIssue{Instance: instance, Issue: issuePreviousGeneration,
+				Reason: "previous generation instance type"})
+		}
+	}
+
+	for _, region := range regions {
+		advices, err := spot.GetSpotSavingsContext(ctx, []string{region}, pattern, instanceOS, 0, 0, 0, spot.SortByInstance, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get spot advice in %s", region)
+		}
+
+		available := make(map[string]bool, len(advices))
+		for _, advice := range advices {
+			available[advice.Instance] = true
+		}
+
+		for _, instance := range instances {
+			if !available[instance] {
+				result.Issues = append(result.Issues, fleetIssue{Region: region, Instance: instance, Issue: issueNotSpotEligible,
+					Reason: "not available as spot instance"})
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+// collectFields collect string values of instance type and availability zone fields in JSON document
+func collectFields(node interface{}, fields map[string]map[string]bool) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if s, ok := value.(string); ok && (key == instanceTypeKey || key == availabilityZoneKey) {
+				if fields[key] == nil {
+					fields[key] = make(map[string]bool)
+				}
+
+				fields[key][s] = true
+
+				continue
+			}
+
+			collectFields(value, fields)
+		}
+	case []interface{}:
+		for _, value := range v {
+			collectFields(value, fields)
+		}
+	}
+}
+
+// zoneRegionRegexp availability zone name: region and zone letter (e.g. us-east-1a, us-gov-west-1b); zone IDs
+// (e.g. use1-az1) do not match
+var zoneRegionRegexp = regexp.MustCompile(`^([a-z]{2}(?:-[a-z]+)+-\d+)[a-z]$`)
+
+// zoneRegions regions of availability zones (e.g. us-east-1 of us-east-1a), default region when there are none;
+// zone IDs and other values that are not zone names are skipped
+func zoneRegions(zones map[string]bool) []string {
+	regions := make(map[string]bool)
+
+	for zone := range zones {
+		if m := zoneRegionRegexp.FindStringSubmatch(zone); m != nil {
+			regions[m[1]] = true
+		}
+	}
+
+	if len(regions) == 0 {
+		return []string{defaultRegion}
+	}
+
+	return sortedKeys(regions)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_collectFields(t *testing.T) {
+	const config = `{
+		"SpotFleetRequestConfig": {
+			"LaunchSpecifications": [
+				{"InstanceType": "m5.large", "Placement": {"AvailabilityZone": "us-east-1a"}},
+				{"InstanceType": "c5.large", "Placement": {"AvailabilityZone": "us-east-1b"}}
+			],
+			"LaunchTemplateConfigs": [{"Overrides": [
+				{"InstanceType": "m5.large", "AvailabilityZone": "eu-west-1a"},
+				{"InstanceType": "r5.large", "WeightedCapacity": 2}
+			]}],
+			"TagSpecifications": [{"Tags": [{"Key": "InstanceType", "Value": "not-a-field"}]}],
+			"InstanceType": ["m4.large"]
+		}
+	}`
+
+	var node interface{}
+	if err := json.Unmarshal([]byte(config), &node); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := make(map[string]map[string]bool)
+	collectFields(node, fields)
+
+	want := map[string]map[string]bool{
+		instanceTypeKey:     {"m5.large": true, "c5.large": true, "r5.large": true},
+		availabilityZoneKey: {"us-east-1a": true, "us-east-1b": true, "eu-west-1a": true},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("collectFields() = %v, want %v", fields, want)
+	}
+}
+
+func Test_zoneRegions(t *testing.T) {
+	tests := []struct {
+		name  string
+		zones []string
+		want  []string
+	}{
+		{name: "no zones", want: []string{defaultRegion}},
+		{name: "zones of one region", zones: []string{"us-east-1a", "us-east-1f"}, want: []string{"us-east-1"}},
+		{name: "zones of several regions", zones: []string{"eu-west-1b", "us-gov-west-1a", "ap-northeast-2c"},
+			want: []string{"ap-northeast-2", "eu-west-1", "us-gov-west-1"}},
+		{name: "zone ids are skipped", zones: []string{"use1-az1", "us-west-2b"}, want: []string{"us-west-2"}},
+		{name: "only zone ids", zones: []string{"use1-az1", "euw1-az3"}, want: []string{defaultRegion}},
+		{name: "region and local zone are skipped", zones: []string{"us-east-1", "us-west-2-lax-1a", ""}, want: []string{defaultRegion}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zones := make(map[string]bool)
+			for _, zone := range tt.zones {
+				zones[zone] = true
+			}
+
+			if got := zoneRegions(zones); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("zoneRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateFleet(t *testing.T) {
+	got, err := validateFleet(context.Background(), []string{"us-east-1", "eu-west-1"}, []string{"m4.large", "m5.large", "x9.huge"}, "linux")
+	if err != nil {
+		t.Fatalf("validateFleet() error = %v", err)
+	}
+
+	want := []fleetIssue{
+		{Instance: "m4.large", Issue: issuePreviousGeneration, Reason: "previous generation instance type"},
+		{Region: "us-east-1", Instance: "x9.huge", Issue: issueNotSpotEligible, Reason: "not available as spot instance"},
+		{Region: "eu-west-1", Instance: "x9.huge", Issue: issueNotSpotEligible, Reason: "not available as spot instance"},
+	}
+	if !reflect.DeepEqual(got.Issues, want) {
+		t.Errorf("validateFleet() issues = %+v, want %+v", got.Issues, want)
+	}
+
+	if _, err = validateFleet(context.Background(), []string{"us-east-1"}, []string{"m5.large"}, "reactos"); err == nil {
+		t.Error("validateFleet() want error on unknown OS")
+	}
+}
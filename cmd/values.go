@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"spotinfo/public/spot" //nolint:gci
+
+	"github.com/pkg/errors"
+)
+
+const (
+	karpenterChart         = "karpenter"
+	clusterAutoscalerChart = "cluster-autoscaler"
+	sparkOperatorChart     = "spark-operator"
+	instanceTypeLabel      = "node.kubernetes.io/instance-type"
+	karpenterCapacityLabel = "karpenter.sh/capacity-type"
+	// allocation strategies: order of generated instance types
+	capacityOptimizedPrioritized = "capacity-optimized-prioritized"
+	lowestPrice                  = "lowest-price"
+	priceCapacityOptimized       = "price-capacity-optimized"
+	karpenterRequirementsFmt     = `# Karpenter NodePool spec.template.spec.requirements
+requirements:
+  - key: %s
+    operator: In
+    values: ["spot"]
+  - key: %s
+    operator: In
+    values:
+%s`
+	karpenterTagsHeader = `# Karpenter EC2NodeClass spec.tags: cost allocation tags of launched instances
+tags:
+`
+	affinityFmt = `# %s chart values: schedule on recommended spot instance types
+affinity:
+  nodeAffinity:
+    requiredDuringSchedulingIgnoredDuringExecution:
+      nodeSelectorTerms:
+        - matchExpressions:
+            - key: %s
+              operator: In
+              values:
+%s`
+)
+
+// allocationOrder sort advices (stable) in priority order of AWS allocation strategy: capacity-optimized-prioritized
+// (least interrupted first, then highest savings), lowest-price (cheapest first) or price-capacity-optimized (lowest
+// interruption adjusted price first); empty strategy keeps result order
+func allocationOrder(advices []spot.Advice, strategy string, penalty float64) error {
+	var less func(a, b *spot.Advice) bool
+
+	switch strategy {
+	case "":
+		return nil
+	case capacityOptimizedPrioritized:
+		less = func(a, b *spot.Advice) bool {
+			if a.Range.Min != b.Range.Min {
+				return a.Range.Min < b.Range.Min
+			}
+
+			return a.Savings > b.Savings
+		}
+	case lowestPrice:
+		less = func(a, b *spot.Advice) bool { return a.Price < b.Price }
+	case priceCapacityOptimized:
+		less = func(a, b *spot.Advice) bool { return a.AdjustedPrice(penalty) < b.AdjustedPrice(penalty) }
+	default:
+		return errors.Errorf("unsupported allocation strategy %q, use %s|%s|%s", strategy, capacityOptimizedPrioritized,
+			lowestPrice, priceCapacityOptimized)
+	}
+
+	sort.SliceStable(advices, func(i, j int) bool { return less(&advices[i], &advices[j]) })
+
+	return nil
+}
+
+// printHelmValues print values snippet with recommended instance types (in result order) for a Helm chart
+func printHelmValues(advices []spot.Advice, chart, strategy string, tags map[string]string) error {
+	if len(advices) == 0 {
+		return errors.New("no instance types to generate values from")
+	}
+
+	if strategy != "" {
+		fmt.Printf("# %s allocation strategy: instance types in priority order\n", strategy)
+	}
+
+	var list strings.Builder
+
+	seen := make(map[string]bool, len(advices))
+
+	for _, advice := range advices {
+		if seen[advice.Instance] {
+			continue
+		}
+
+		seen[advice.Instance] = true
+
+		fmt.Fprintf(&list, "%s- %s\n", strings.Repeat(" ", 6), advice.Instance) //nolint:gomnd
+	}
+
+	switch chart {
+	case karpenterChart:
+		fmt.Printf(karpenterRequirementsFmt, karpenterCapacityLabel, instanceTypeLabel, list.String())
+		printKarpenterTags(tags)
+	case clusterAutoscalerChart, sparkOperatorChart:
+		if len(tags) > 0 {
+			log.Printf("warning: %s values have no AWS resource tags, --tags ignored", chart)
+		}
+
+		fmt.Printf(affinityFmt, chart, instanceTypeLabel, indent(list.String(), 10)) //nolint:gomnd
+	default:
+		return errors.Errorf("unsupported chart %q, use %s|%s|%s", chart, karpenterChart, clusterAutoscalerChart, sparkOperatorChart)
+	}
+
+	return nil
+}
+
+// printKarpenterTags print EC2NodeClass tags sorted by key, values quoted
+func printKarpenterTags(tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	fmt.Print(karpenterTagsHeader)
+
+	for _, key := range keys {
+		fmt.Printf("  %s: %s\n", strconv.Quote(key), strconv.Quote(tags[key]))
+	}
+}
+
+func indent(text string, n int) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.Repeat(" ", n) + line
+		}
+	}
+
+	return strings.Join(lines, "")
+}
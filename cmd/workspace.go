@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3" //nolint:gci
+)
+
+const workspaceConfigFile = ".spotinfo.yaml"
+
+// workspaceFlags flags set from workspace config, rather than on command line
+var workspaceFlags = make(map[string]bool)
+
+// findWorkspaceConfig search workspace config file upward from directory; empty path when not found
+func findWorkspaceConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve working directory")
+	}
+
+	for {
+		path := filepath.Join(dir, workspaceConfigFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+
+		dir = parent
+	}
+}
+
+// loadWorkspaceConfig read workspace config: advice query flag names with scalar or list values
+func loadWorkspaceConfig(path string) (map[string][]string, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	var raw map[string]interface{}
+	if err = yaml.Unmarshal(bytes, &raw); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+
+	known := make(map[string]bool)
+	for _, f := range advisorFlags() {
+		known[f.Names()[0]] = true
+	}
+
+	config := make(map[string][]string, len(raw))
+
+	for name, value := range raw {
+		if !known[name] {
+			return nil, errors.Errorf("unknown option %q in %s", name, path)
+		}
+
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				config[name] = append(config[name], fmt.Sprint(item))
+			}
+		case map[string]interface{}:
+			return nil, errors.Errorf("invalid value of option %q in %s", name, path)
+		default:
+			config[name] = []string{fmt.Sprint(v)}
+		}
+	}
+
+	return config, nil
+}
+
+// applyWorkspaceConfig set flags from workspace config found upward from current directory; explicitly set flags take
+// precedence; returns config path, if any
+func applyWorkspaceConfig(c *cli.Context) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get working directory")
+	}
+
+	path, err := findWorkspaceConfig(cwd)
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	config, err := loadWorkspaceConfig(path)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if c.IsSet(name) {
+			continue
+		}
+
+		for _, value := range config[name] {
+			if err = c.Set(name, value); err != nil {
+				return "", errors.Wrapf(err, "invalid value of option %q in %s", name, path)
+			}
+		}
+
+		workspaceFlags[name] = true
+	}
+
+	return path, nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// tempWorkspace create temporary directory tree with workspace config in root and nested project directory
+func tempWorkspace(t *testing.T, config string) (root, nested string) {
+	t.Helper()
+
+	root, err := ioutil.TempDir("", "spotinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(root) })
+
+	nested = filepath.Join(root, "project", "service")
+	if err = os.MkdirAll(nested, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if config != "" {
+		if err = ioutil.WriteFile(filepath.Join(root, workspaceConfigFile), []byte(config), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root, nested
+}
+
+func Test_findWorkspaceConfig(t *testing.T) {
+	root, nested := tempWorkspace(t, "type: m5\n")
+
+	// directory named as config file is not a config
+	if err := os.Mkdir(filepath.Join(nested, workspaceConfigFile), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	_, empty := tempWorkspace(t, "")
+
+	// only configs inside test tree are expected; one above temp directory would be found for any tree
+	above, err := findWorkspaceConfig(filepath.Dir(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{name: "config in directory", dir: root, want: filepath.Join(root, workspaceConfigFile)},
+		{name: "config in parent directory", dir: nested, want: filepath.Join(root, workspaceConfigFile)},
+		{name: "relative path", dir: filepath.Join(nested, ".."), want: filepath.Join(root, workspaceConfigFile)},
+		{name: "no config", dir: empty, want: above},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findWorkspaceConfig(tt.dir)
+			if err != nil {
+				t.Fatalf("findWorkspaceConfig() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("findWorkspaceConfig() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadWorkspaceConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    map[string][]string
+		wantErr string
+	}{
+		{
+			name:   "scalar values",
+			config: "type: ^m5\\.\ncpu: 4\nstrict: true\n",
+			want:   map[string][]string{"type": {"^m5\\."}, "cpu": {"4"}, "strict": {"true"}},
+		},
+		{
+			name:   "list value",
+			config: "region:\n  - eu-west-1\n  - us-west-2\n",
+			want:   map[string][]string{"region": {"eu-west-1", "us-west-2"}},
+		},
+		{
+			name:   "empty config",
+			config: "# no defaults\n",
+			want:   map[string][]string{},
+		},
+		{
+			name:    "unknown key",
+			config:  "regions: eu-west-1\n",
+			wantErr: `unknown option "regions"`,
+		},
+		{
+			name:    "map value",
+			config:  "region:\n  name: eu-west-1\n",
+			wantErr: `invalid value of option "region"`,
+		},
+		{
+			name:    "invalid yaml",
+			config:  "type: [m5\n",
+			wantErr: "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, _ := tempWorkspace(t, tt.config)
+
+			got, err := loadWorkspaceConfig(filepath.Join(root, workspaceConfigFile))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadWorkspaceConfig() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("loadWorkspaceConfig() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadWorkspaceConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applyWorkspaceConfig(t *testing.T) {
+	const config = "type: m5\ncpu: 4\nregion:\n  - eu-west-1\n  - us-west-2\n"
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantType   string
+		wantCPU    int
+		wantRegion []string
+		wantFlags  map[string]bool
+	}{
+		{
+			name:       "config values",
+			wantType:   "m5",
+			wantCPU:    4,
+			wantRegion: []string{"eu-west-1", "us-west-2"},
+			wantFlags:  map[string]bool{"type": true, "cpu": true, "region": true},
+		},
+		{
+			name:       "explicit flags take precedence",
+			args:       []string{"--type", "c5", "--region", "ap-south-1"},
+			wantType:   "c5",
+			wantCPU:    4,
+			wantRegion: []string{"ap-south-1"},
+			wantFlags:  map[string]bool{"cpu": true},
+		},
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Chdir(cwd) //nolint:errcheck
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, nested := tempWorkspace(t, config)
+			if err := os.Chdir(nested); err != nil {
+				t.Fatal(err)
+			}
+
+			workspaceFlags = make(map[string]bool)
+
+			var (
+				gotType   string
+				gotCPU    int
+				gotRegion []string
+			)
+
+			app := &cli.App{
+				Flags: advisorFlags(),
+				Action: func(c *cli.Context) error {
+					if _, err := applyWorkspaceConfig(c); err != nil {
+						return err
+					}
+
+					gotType, gotCPU, gotRegion = c.String("type"), c.Int("cpu"), c.StringSlice("region")
+
+					return nil
+				},
+			}
+
+			if err := app.Run(append([]string{"spotinfo"}, tt.args...)); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if gotType != tt.wantType || gotCPU != tt.wantCPU || !reflect.DeepEqual(gotRegion, tt.wantRegion) {
+				t.Errorf("got --type %q --cpu %d --region %v, want --type %q --cpu %d --region %v",
+					gotType, gotCPU, gotRegion, tt.wantType, tt.wantCPU, tt.wantRegion)
+			}
+
+			if !reflect.DeepEqual(workspaceFlags, tt.wantFlags) {
+				t.Errorf("workspaceFlags = %v, want %v", workspaceFlags, tt.wantFlags)
+			}
+		})
+	}
+
+	workspaceFlags = make(map[string]bool)
+}
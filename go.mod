@@ -3,10 +3,11 @@ module spotinfo
 go 1.16
 
 require (
-	github.com/cpuguy83/go-md2man/v2 v2.0.0 // indirect
+	github.com/cpuguy83/go-md2man/v2 v2.0.0
 	github.com/jedib0t/go-pretty/v6 v6.1.0
 	github.com/pkg/errors v0.9.1
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/stretchr/testify v1.7.0 // indirect
 	github.com/urfave/cli/v2 v2.3.0
+	gopkg.in/yaml.v3 v3.0.1
 )
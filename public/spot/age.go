@@ -0,0 +1,24 @@
+package spot
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EmbeddedDataDate generation date (RFC3339) of the data feeds embedded during the build, set with ldflags
+var EmbeddedDataDate = ""
+
+// EmbeddedDataAge age of the embedded data feeds; error if generation date is unknown
+func EmbeddedDataAge() (time.Duration, error) {
+	if EmbeddedDataDate == "" {
+		return 0, errors.New("embedded data generation date is unknown")
+	}
+
+	date, err := time.Parse(time.RFC3339, EmbeddedDataDate)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid embedded data generation date %q", EmbeddedDataDate)
+	}
+
+	return time.Since(date), nil
+}
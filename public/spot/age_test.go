@@ -0,0 +1,35 @@
+package spot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmbeddedDataAge(t *testing.T) {
+	defer func(date string) { EmbeddedDataDate = date }(EmbeddedDataDate)
+
+	tests := []struct {
+		name    string
+		date    string
+		minAge  time.Duration
+		wantErr bool
+	}{
+		{name: "known date", date: time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339), minAge: 47 * time.Hour},
+		{name: "fail on unknown date", date: "", wantErr: true},
+		{name: "fail on invalid date", date: "2021-05-12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			EmbeddedDataDate = tt.date
+			got, err := EmbeddedDataAge()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EmbeddedDataAge() error = %v, wantErr %v", err, tt.wantErr)
+				return //nolint:nlreturn
+			}
+			if got < tt.minAge {
+				t.Errorf("EmbeddedDataAge() = %v, want >= %v", got, tt.minAge)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package spot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// continents and geographic names mapped to AWS region code prefixes
+	// longer names first: "north america" must be matched before "america"
+	askLocations = []struct {
+		name     *regexp.Regexp
+		prefixes []string
+	}{
+		{regexp.MustCompile(`\bnorth america\b`), []string{"us-", "ca-"}},
+		{regexp.MustCompile(`\bsouth america\b`), []string{"sa-"}},
+		{regexp.MustCompile(`\bmiddle east\b`), []string{"me-", "il-"}},
+		{regexp.MustCompile(`\bamericas?\b`), []string{"us-", "ca-", "sa-"}},
+		{regexp.MustCompile(`\b(europe|eu)\b`), []string{"eu-"}},
+		// bare "us" is a pronoun ("find us cheap instances"): region only after in/from
+		{regexp.MustCompile(`\b(usa|united states|(in|from) (the )?us)\b`), []string{"us-"}},
+		{regexp.MustCompile(`\bcanada\b`), []string{"ca-"}},
+		{regexp.MustCompile(`\b(asia|apac)\b`), []string{"ap-"}},
+		{regexp.MustCompile(`\bafrica\b`), []string{"af-"}},
+	}
+	// instance families mapped to instance type patterns
+	askFamilies = []struct {
+		name    *regexp.Regexp
+		pattern string
+	}{
+		{regexp.MustCompile(`\b(arm|arm64|graviton)\b`), `^(a1|[a-z]+\d+g[a-z]*)\.`},
+		{regexp.MustCompile(`\bamd\b`), `^[a-z]+\d+a[a-z]*\.`},
+		{regexp.MustCompile(`\bgpus?\b`), `^(p|g)\d`},
+	}
+	askRegion       = regexp.MustCompile(`\b[a-z]{2}(-gov)?-[a-z]+-\d\b`)
+	askInstanceType = regexp.MustCompile(`\b[a-z][a-z0-9-]*\d[a-z0-9-]*\.[a-z0-9]+\b`)
+	askCPU          = regexp.MustCompile(`\b(\d+)[\s-]*(v?cpus?|cores?)\b`)
+	askMemory       = regexp.MustCompile(`\b(\d+)\s*(gb|gib|g)\b`)
+	askPrice        = regexp.MustCompile(`\b(under|below|less than|cheaper than|max|up to)\s*\$?(\d+(\.\d+)?|\.\d+)`)
+	askOS           = regexp.MustCompile(`\b(windows|linux)\b`)
+	// sort keywords
+	askSorts = []struct {
+		name     *regexp.Regexp
+		sortBy   int
+		sortDesc bool
+	}{
+		{regexp.MustCompile(`\b(cheapest|lowest price|least expensive)\b`), SortByPrice, false},
+		{regexp.MustCompile(`\bmost expensive\b`), SortByPrice, true},
+		{regexp.MustCompile(`\b(most|biggest|best|highest) savings?\b`), SortBySavings, true},
+		{regexp.MustCompile(`\b(most reliable|least interrupted|stable|stablest)\b`), SortByRange, false},
+	}
+	// sort names (same as CLI --sort values)
+	sortNames = map[int]string{
+		SortByRange:         "interruption",
+		SortByInstance:      "type",
+		SortBySavings:       "savings",
+		SortByPrice:         "price",
+		SortByRegion:        "region",
+		SortByAdjustedPrice: "adjusted-price",
+	}
+)
+
+// Query structured spot query options parsed from a natural-language request
+type Query struct {
+	Regions  []string
+	Pattern  string
+	OS       string
+	CPU      int
+	Memory   int
+	Price    float64
+	SortBy   int
+	SortDesc bool
+}
+
+// String human readable query description
+func (q *Query) String() string {
+	parts := make([]string, 0)
+
+	if len(q.Regions) > 0 {
+		parts = append(parts, "region="+strings.Join(q.Regions, ","))
+	}
+
+	if q.Pattern != "" {
+		parts = append(parts, "type="+q.Pattern)
+	}
+
+	if q.OS != "" {
+		parts = append(parts, "os="+q.OS)
+	}
+
+	if q.CPU != 0 {
+		parts = append(parts, fmt.Sprintf("cpu=%d", q.CPU))
+	}
+
+	if q.Memory != 0 {
+		parts = append(parts, fmt.Sprintf("memory=%d", q.Memory))
+	}
+
+	if q.Price != 0 {
+		parts = append(parts, fmt.Sprintf("price=%v", q.Price))
+	}
+
+	order := "asc"
+	if q.SortDesc {
+		order = "desc"
+	}
+
+	parts = append(parts, fmt.Sprintf("sort=%s", sortNames[q.SortBy]), "order="+order)
+
+	return strings.Join(parts, " ")
+}
+
+// ParseQuery parse simple natural-language constraints (rule-based) into query options
+// e.g. "cheapest 8 vCPU ARM in Europe under $0.20"
+func ParseQuery(ctx context.Context, text string) (*Query, error) { //nolint:gocognit,gocyclo,funlen
+	var (
+		q       = Query{SortBy: SortByRange}
+		matched bool
+	)
+
+	s := strings.ToLower(text)
+
+	// explicit AWS regions
+	for _, r := range askRegion.FindAllString(s, -1) {
+		q.Regions = append(q.Regions, r)
+		matched = true
+	}
+
+	s = askRegion.ReplaceAllString(s, " ")
+
+	// continents and countries
+	var prefixes []string
+
+	for _, loc := range askLocations {
+		if loc.name.MatchString(s) {
+			prefixes = append(prefixes, loc.prefixes...)
+			s = loc.name.ReplaceAllString(s, " ")
+		}
+	}
+
+	if len(prefixes) > 0 {
+		regions, err := regionsWithPrefix(ctx, prefixes)
+		if err != nil {
+			return nil, err
+		}
+
+		q.Regions = append(q.Regions, regions...)
+		matched = true
+	}
+
+	// explicit instance type or instance family
+	if t := askInstanceType.FindString(s); t != "" {
+		q.Pattern = "^" + regexp.QuoteMeta(t) + "$"
+		s = strings.Replace(s, t, " ", 1)
+	}
+
+	for _, f := range askFamilies {
+		if !f.name.MatchString(s) {
+			continue
+		}
+
+		if q.Pattern != "" {
+			return nil, errors.Errorf("conflicting instance type constraints in query: %q", text)
+		}
+
+		q.Pattern = f.pattern
+	}
+
+	if q.Pattern != "" {
+		matched = true
+	}
+
+	// price: parse before memory and cpu to consume numbers
+	if m := askPrice.FindStringSubmatch(s); m != nil {
+		price, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse price %q", m[2])
+		}
+
+		q.Price = price
+		s = strings.Replace(s, m[0], " ", 1)
+		matched = true
+	}
+
+	if m := askCPU.FindStringSubmatch(s); m != nil {
+		q.CPU, _ = strconv.Atoi(m[1])
+		matched = true
+	}
+
+	if m := askMemory.FindStringSubmatch(s); m != nil {
+		q.Memory, _ = strconv.Atoi(m[1])
+		matched = true
+	}
+
+	if m := askOS.FindStringSubmatch(s); m != nil {
+		q.OS = m[1]
+		matched = true
+	}
+
+	for _, srt := range askSorts {
+		if srt.name.MatchString(s) {
+			q.SortBy = srt.sortBy
+			q.SortDesc = srt.sortDesc
+			matched = true
+
+			break
+		}
+	}
+
+	if !matched {
+		return nil, errors.Errorf("no known constraints found in query: %q", text)
+	}
+
+	return &q, nil
+}
+
+func regionsWithPrefix(ctx context.Context, prefixes []string) ([]string, error) {
+	data, err := getAdvisorData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var regions []string
+
+	for region := range data.Regions {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(region, prefix) {
+				regions = append(regions, region)
+
+				break
+			}
+		}
+	}
+
+	sort.Strings(regions)
+
+	return regions, nil
+}
@@ -0,0 +1,103 @@
+package spot
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//nolint:funlen
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *Query
+		wantErr bool
+	}{
+		{
+			name: "cheapest ARM with cpu and price in region",
+			text: "cheapest 8 vCPU ARM in us-east-1 under $0.20",
+			want: &Query{Regions: []string{"us-east-1"}, Pattern: `^(a1|[a-z]+\d+g[a-z]*)\.`, CPU: 8, Price: 0.2, SortBy: SortByPrice},
+		},
+		{
+			name: "memory, os and savings",
+			text: "windows with 32 GiB memory and the biggest savings in eu-west-1",
+			want: &Query{Regions: []string{"eu-west-1"}, OS: "windows", Memory: 32, SortBy: SortBySavings, SortDesc: true},
+		},
+		{
+			name: "explicit instance type",
+			text: "most reliable m5.xlarge in us-east-2",
+			want: &Query{Regions: []string{"us-east-2"}, Pattern: `^m5\.xlarge$`, SortBy: SortByRange},
+		},
+		{
+			name: "cores and price without currency",
+			text: "16 cores below 1.5",
+			want: &Query{CPU: 16, Price: 1.5, SortBy: SortByRange},
+		},
+		{
+			name: "us pronoun is not a region",
+			text: "find us cheap 4 vCPU instances",
+			want: &Query{CPU: 4, SortBy: SortByRange},
+		},
+		{
+			name:    "fail on conflicting instance constraints",
+			text:    "gpu arm",
+			wantErr: true,
+		},
+		{
+			name:    "fail on unknown query",
+			text:    "something nice",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(context.Background(), tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return //nolint:nlreturn
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuery_continent(t *testing.T) {
+	got, err := ParseQuery(context.Background(), "8 vcpu in Europe")
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+
+	if len(got.Regions) == 0 {
+		t.Fatal("ParseQuery() no regions resolved for Europe")
+	}
+
+	for _, r := range got.Regions {
+		if !strings.HasPrefix(r, "eu-") {
+			t.Errorf("ParseQuery() region = %v, want eu-* region", r)
+		}
+	}
+}
+
+func TestParseQuery_us(t *testing.T) {
+	for _, text := range []string{"4 vcpu in the US", "cheapest 4 vcpu from us", "4 vcpu in USA"} {
+		got, err := ParseQuery(context.Background(), text)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error = %v", text, err)
+		}
+
+		if len(got.Regions) == 0 {
+			t.Fatalf("ParseQuery(%q) no regions resolved for US", text)
+		}
+
+		for _, r := range got.Regions {
+			if !strings.HasPrefix(r, "us-") {
+				t.Errorf("ParseQuery(%q) region = %v, want us-* region", text, r)
+			}
+		}
+	}
+}
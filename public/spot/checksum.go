@@ -0,0 +1,77 @@
+package spot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// expected SHA-256 checksums of downloaded data feeds, keyed by feed file name
+var feedChecksums = map[string]string{}
+
+// SetFeedChecksums require downloaded data feeds to match SHA-256 checksums (hex), keyed by feed file name
+// (e.g. spot-advisor-data.json, spot.js); embedded data is used when a downloaded feed does not match
+func SetFeedChecksums(checksums map[string]string) {
+	feedChecksums = make(map[string]string, len(checksums))
+	for name, sum := range checksums {
+		feedChecksums[name] = strings.ToLower(sum)
+	}
+}
+
+// ParseChecksums parse checksums in sha256sum format: "<hex checksum>  <file name>" per line
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	const fields = 2
+
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		parts := strings.Fields(text)
+		if len(parts) != fields {
+			return nil, errors.Errorf("invalid checksum line %d: %q", line, text)
+		}
+
+		if sum, err := hex.DecodeString(parts[0]); err != nil || len(sum) != sha256.Size {
+			return nil, errors.Errorf("invalid SHA-256 checksum on line %d: %q", line, parts[0])
+		}
+
+		// sha256sum marks binary mode files with '*'
+		checksums[path.Base(strings.TrimPrefix(parts[1], "*"))] = parts[0]
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read checksums")
+	}
+
+	return checksums, nil
+}
+
+func sha256sum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyChecksum verify downloaded feed content against expected checksum, if any
+func verifyChecksum(url string, body []byte) error {
+	expected, ok := feedChecksums[path.Base(url)]
+	if !ok {
+		return nil
+	}
+
+	if actual := sha256sum(body); actual != expected {
+		return errors.Errorf("checksum mismatch for %s: got %s, want %s", url, actual, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package spot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseChecksums(t *testing.T) {
+	const sum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "sha256sum format with comments and binary marker",
+			input: "# feeds\n" + sum + "  spot-advisor-data.json\n" + sum + " *data/spot.js\n",
+			want:  map[string]string{"spot-advisor-data.json": sum, "spot.js": sum},
+		},
+		{
+			name:    "fail on invalid checksum",
+			input:   "abc  spot.js",
+			wantErr: true,
+		},
+		{
+			name:    "fail on invalid line",
+			input:   sum,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChecksums(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseChecksums() error = %v, wantErr %v", err, tt.wantErr)
+				return //nolint:nlreturn
+			}
+			for name, sum := range tt.want {
+				if got[name] != sum {
+					t.Errorf("ParseChecksums() [%v] = %v, want %v", name, got[name], sum)
+				}
+			}
+		})
+	}
+}
+
+func Test_dataLazyLoad_checksum(t *testing.T) {
+	const body = `{"ranges":[{"index":0,"label":"<5%","dots":0,"max":5}]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	defer SetFeedChecksums(nil)
+
+	url := server.URL + "/spot-advisor-data.json"
+	sum := sha256.Sum256([]byte(body))
+
+	SetFeedChecksums(map[string]string{"spot-advisor-data.json": hex.EncodeToString(sum[:])})
+
+	got, err := dataLazyLoad(context.Background(), url, 1*time.Second, embeddedSpotData)
+	if err != nil || got.Embedded {
+		t.Errorf("dataLazyLoad() matching checksum: error = %v, embedded = %v, want downloaded data", err, got.Embedded)
+	}
+
+	SetFeedChecksums(map[string]string{"spot-advisor-data.json": strings.Repeat("0", sha256.Size*2)})
+
+	got, err = dataLazyLoad(context.Background(), url, 1*time.Second, embeddedSpotData)
+	if err != nil || !got.Embedded {
+		t.Errorf("dataLazyLoad() checksum mismatch: error = %v, embedded = %v, want embedded data", err, got.Embedded)
+	}
+}
@@ -0,0 +1,88 @@
+package spot
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// known compliance tags
+const (
+	ComplianceGDPR            = "gdpr"
+	ComplianceUKGDPR          = "uk-gdpr"
+	ComplianceFedRAMPModerate = "fedramp-moderate"
+	ComplianceFedRAMPHigh     = "fedramp-high"
+	ComplianceITAR            = "itar"
+	ComplianceChina           = "china"
+)
+
+// region compliance metadata: regions without special data-residency or regulatory status are not listed
+var regionCompliance = map[string][]string{
+	// EU/EEA regions
+	"eu-central-1": {ComplianceGDPR},
+	"eu-west-1":    {ComplianceGDPR},
+	"eu-west-3":    {ComplianceGDPR},
+	"eu-north-1":   {ComplianceGDPR},
+	"eu-south-1":   {ComplianceGDPR},
+	"eu-south-2":   {ComplianceGDPR},
+	// United Kingdom
+	"eu-west-2": {ComplianceUKGDPR},
+	// US regions with FedRAMP Moderate authorization
+	"us-east-1": {ComplianceFedRAMPModerate},
+	"us-east-2": {ComplianceFedRAMPModerate},
+	"us-west-1": {ComplianceFedRAMPModerate},
+	"us-west-2": {ComplianceFedRAMPModerate},
+	// AWS GovCloud (US)
+	"us-gov-east-1": {ComplianceFedRAMPHigh, ComplianceITAR},
+	"us-gov-west-1": {ComplianceFedRAMPHigh, ComplianceITAR},
+	// AWS China (isolated partition)
+	"cn-north-1":     {ComplianceChina},
+	"cn-northwest-1": {ComplianceChina},
+}
+
+// RegionCompliance get compliance tags of AWS region
+func RegionCompliance(region string) []string {
+	return regionCompliance[region]
+}
+
+// matchCompliance check if any region tag matches any of the requested tags;
+// requested tag matches exact tag or tag family, i.e. "fedramp" matches "fedramp-high"
+func matchCompliance(region string, tags []string) bool {
+	for _, rt := range regionCompliance[region] {
+		for _, t := range tags {
+			if matchComplianceTag(rt, t) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchComplianceTag(regionTag, tag string) bool {
+	tag = strings.ToLower(tag)
+
+	return regionTag == tag || strings.HasPrefix(regionTag, tag+"-")
+}
+
+func validateCompliance(tags []string) error {
+	known := []string{ComplianceGDPR, ComplianceUKGDPR, ComplianceFedRAMPModerate, ComplianceFedRAMPHigh, ComplianceITAR, ComplianceChina}
+
+	for _, t := range tags {
+		valid := false
+
+		for _, k := range known {
+			if matchComplianceTag(k, t) {
+				valid = true
+
+				break
+			}
+		}
+
+		if !valid {
+			return errors.Errorf("unknown compliance tag %q, must be one of: %s", t, strings.Join(known, ", "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package spot
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_matchCompliance(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		tags   []string
+		want   bool
+	}{
+		{name: "gdpr region", region: "eu-west-1", tags: []string{"gdpr"}, want: true},
+		{name: "uk region is not gdpr", region: "eu-west-2", tags: []string{"gdpr"}, want: false},
+		{name: "fedramp family matches fedramp-high", region: "us-gov-west-1", tags: []string{"fedramp"}, want: true},
+		{name: "any of tags", region: "us-east-1", tags: []string{"gdpr", "FedRAMP"}, want: true},
+		{name: "untagged region", region: "ap-south-1", tags: []string{"gdpr"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchCompliance(tt.region, tt.tags); got != tt.want {
+				t.Errorf("matchCompliance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSpotSavings_withCompliance(t *testing.T) {
+	got, err := GetSpotSavingsContext(context.Background(), []string{"all"}, "", "linux", 0, 0, 0, SortByRange, false, WithCompliance("gdpr"))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	if len(got) == 0 {
+		t.Fatal("GetSpotSavings() returned no advices for gdpr regions")
+	}
+
+	for _, advice := range got {
+		if !matchCompliance(advice.Region, []string{"gdpr"}) {
+			t.Errorf("GetSpotSavings() region %v is not gdpr compliant", advice.Region)
+		}
+	}
+
+	if _, err = GetSpotSavingsContext(context.Background(), []string{"all"}, "", "linux", 0, 0, 0, SortByRange, false, WithCompliance("hipaa")); err == nil {
+		t.Error("GetSpotSavings() expected error for unknown compliance tag")
+	}
+}
@@ -0,0 +1,59 @@
+package spot
+
+import (
+	"context"
+	"sort"
+)
+
+// Coverage regions covered by spot advisor (interruption frequency and savings) and spot pricing data feeds
+type Coverage struct {
+	// regions with both interruption data and spot prices
+	Covered []string `json:"covered"`
+	// regions with interruption data, but no spot prices: results have zero price
+	AdvisorOnly []string `json:"advisor_only"` //nolint:tagliatelle
+	// regions with spot prices, but no interruption data: queries fail
+	PricingOnly []string `json:"pricing_only"` //nolint:tagliatelle
+}
+
+// DataCoverage compare regions of spot advisor and spot pricing data feeds (sorted)
+func DataCoverage(ctx context.Context) (*Coverage, error) {
+	advisor, err := getAdvisorData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, err := getSpotPriceData(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	priced := make(map[string]bool)
+
+	for _, regions := range pricing.os {
+		for region := range regions {
+			priced[region] = true
+		}
+	}
+
+	result := Coverage{Covered: []string{}, AdvisorOnly: []string{}, PricingOnly: []string{}}
+
+	for region := range advisor.Regions {
+		if priced[region] {
+			result.Covered = append(result.Covered, region)
+		} else {
+			result.AdvisorOnly = append(result.AdvisorOnly, region)
+		}
+	}
+
+	for region := range priced {
+		if _, ok := advisor.Regions[region]; !ok {
+			result.PricingOnly = append(result.PricingOnly, region)
+		}
+	}
+
+	sort.Strings(result.Covered)
+	sort.Strings(result.AdvisorOnly)
+	sort.Strings(result.PricingOnly)
+
+	return &result, nil
+}
@@ -0,0 +1,39 @@
+package spot
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDataCoverage(t *testing.T) {
+	got, err := DataCoverage(context.Background())
+	if err != nil {
+		t.Fatalf("DataCoverage() error = %v", err)
+	}
+
+	if len(got.Covered) == 0 {
+		t.Fatal("DataCoverage() no covered regions")
+	}
+
+	seen := make(map[string]bool)
+
+	for _, regions := range [][]string{got.Covered, got.AdvisorOnly, got.PricingOnly} {
+		for _, region := range regions {
+			if seen[region] {
+				t.Errorf("DataCoverage() region %s reported twice", region)
+			}
+
+			seen[region] = true
+		}
+	}
+
+	regions, err := Regions(context.Background())
+	if err != nil {
+		t.Fatalf("Regions() error = %v", err)
+	}
+
+	if len(regions) != len(got.Covered)+len(got.AdvisorOnly) {
+		t.Errorf("DataCoverage() covered + advisor only = %d regions, want %d advisor regions",
+			len(got.Covered)+len(got.AdvisorOnly), len(regions))
+	}
+}
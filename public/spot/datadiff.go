@@ -0,0 +1,114 @@
+package spot
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// kinds of data changes
+const (
+	DataAdded   = "added"
+	DataRemoved = "removed"
+	DataChanged = "changed"
+)
+
+// DataChange change of instance type advice between two spot advisor datasets
+type DataChange struct {
+	Region     string `json:"region"`
+	OS         string `json:"os"`
+	Instance   string `json:"instance"`
+	Change     string `json:"change"`                // added, removed or changed
+	OldRange   string `json:"old_range,omitempty"`   //nolint:tagliatelle
+	NewRange   string `json:"new_range,omitempty"`   //nolint:tagliatelle
+	OldSavings int    `json:"old_savings,omitempty"` //nolint:tagliatelle
+	NewSavings int    `json:"new_savings,omitempty"` //nolint:tagliatelle
+}
+
+// DiffAdvisorData compare two spot advisor datasets (spot-advisor-data.json): instance types added, removed or with
+// changed interruption range or savings; sorted by region, OS and instance type
+func DiffAdvisorData(older, newer []byte) ([]DataChange, error) {
+	var o, n advisorData
+
+	if err := json.Unmarshal(older, &o); err != nil {
+		return nil, errors.Wrap(err, "failed to parse old spot advisor data")
+	}
+
+	if err := json.Unmarshal(newer, &n); err != nil {
+		return nil, errors.Wrap(err, "failed to parse new spot advisor data")
+	}
+
+	var changes []DataChange
+
+	regions := make(map[string]bool)
+	for region := range o.Regions {
+		regions[region] = true
+	}
+
+	for region := range n.Regions {
+		regions[region] = true
+	}
+
+	for region := range regions {
+		changes = append(changes, diffRegionAdvices(&o, &n, region, "linux", o.Regions[region].Linux, n.Regions[region].Linux)...)
+		changes = append(changes, diffRegionAdvices(&o, &n, region, "windows", o.Regions[region].Windows, n.Regions[region].Windows)...)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Region != changes[j].Region {
+			return changes[i].Region < changes[j].Region
+		}
+
+		if changes[i].OS != changes[j].OS {
+			return changes[i].OS < changes[j].OS
+		}
+
+		return changes[i].Instance < changes[j].Instance
+	})
+
+	return changes, nil
+}
+
+func diffRegionAdvices(o, n *advisorData, region, os string, older, newer map[string]advice) []DataChange {
+	var changes []DataChange
+
+	for instance, oa := range older {
+		c := DataChange{Region: region, OS: os, Instance: instance, OldRange: o.rangeLabel(oa.Range), OldSavings: oa.Savings}
+
+		na, ok := newer[instance]
+		if !ok {
+			c.Change = DataRemoved
+			changes = append(changes, c)
+
+			continue
+		}
+
+		c.NewRange, c.NewSavings = n.rangeLabel(na.Range), na.Savings
+		if c.OldRange != c.NewRange || c.OldSavings != c.NewSavings {
+			c.Change = DataChanged
+			changes = append(changes, c)
+		}
+	}
+
+	for instance, na := range newer {
+		if _, ok := older[instance]; !ok {
+			changes = append(changes, DataChange{
+				Region: region, OS: os, Instance: instance, Change: DataAdded, NewRange: n.rangeLabel(na.Range), NewSavings: na.Savings,
+			})
+		}
+	}
+
+	return changes
+}
+
+// rangeLabel label of interruption range by index
+func (d *advisorData) rangeLabel(index int) string {
+	for _, r := range d.Ranges {
+		if r.Index == index {
+			return r.Label
+		}
+	}
+
+	return "unknown"
+}
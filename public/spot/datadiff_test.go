@@ -0,0 +1,39 @@
+package spot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffAdvisorData(t *testing.T) {
+	const (
+		ranges = `"ranges":[{"index":0,"label":"<5%","max":5},{"index":1,"label":"5-10%","max":11}]`
+		older  = `{` + ranges + `,"spot_advisor":{"us-east-1":{"Linux":{"m5.large":{"r":0,"s":70},"m5.xlarge":{"r":0,"s":60},"c5.large":{"r":1,"s":50}},"Windows":{}}}}`
+		newer  = `{` + ranges + `,"spot_advisor":{"us-east-1":{"Linux":{"m5.large":{"r":1,"s":70},"m5.xlarge":{"r":0,"s":60},"m6i.large":{"r":0,"s":65}}},` +
+			`"eu-west-1":{"Windows":{"m5.large":{"r":0,"s":40}}}}}`
+	)
+
+	want := []DataChange{
+		{Region: "eu-west-1", OS: "windows", Instance: "m5.large", Change: DataAdded, NewRange: "<5%", NewSavings: 40},
+		{Region: "us-east-1", OS: "linux", Instance: "c5.large", Change: DataRemoved, OldRange: "5-10%", OldSavings: 50},
+		{Region: "us-east-1", OS: "linux", Instance: "m5.large", Change: DataChanged, OldRange: "<5%", NewRange: "5-10%", OldSavings: 70, NewSavings: 70},
+		{Region: "us-east-1", OS: "linux", Instance: "m6i.large", Change: DataAdded, NewRange: "<5%", NewSavings: 65},
+	}
+
+	got, err := DiffAdvisorData([]byte(older), []byte(newer))
+	if err != nil {
+		t.Fatalf("DiffAdvisorData() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffAdvisorData() = %+v, want %+v", got, want)
+	}
+
+	if got, err = DiffAdvisorData([]byte(older), []byte(older)); err != nil || len(got) != 0 {
+		t.Errorf("DiffAdvisorData() same data = %v, error = %v, want no changes", got, err)
+	}
+
+	if _, err = DiffAdvisorData([]byte(older), []byte("<html>")); err == nil {
+		t.Error("DiffAdvisorData() want error on invalid data")
+	}
+}
@@ -0,0 +1,49 @@
+package spot
+
+import "strings"
+
+// instance generations
+const (
+	GenerationCurrent  = "current"
+	GenerationPrevious = "previous"
+)
+
+// previous generation instance families, as listed by AWS
+var previousGenerationFamilies = map[string]bool{
+	"c1": true, "c3": true, "c4": true, "cc2": true, "cr1": true, "g2": true, "g3": true, "g3s": true,
+	"hs1": true, "i2": true, "m1": true, "m2": true, "m3": true, "m4": true, "p2": true,
+	"r3": true, "r4": true, "t1": true,
+}
+
+// instance family launch year (general availability); families not listed are unknown
+var familyLaunchYear = map[string]int{
+	"m1": 2006, "c1": 2008, "m2": 2009, "t1": 2010, "cc2": 2011, "hs1": 2012, "m3": 2012,
+	"c3": 2013, "cr1": 2013, "g2": 2013, "i2": 2013, "r3": 2014, "t2": 2014,
+	"c4": 2015, "d2": 2015, "m4": 2015,
+	"p2": 2016, "r4": 2016, "x1": 2016, "g3": 2017, "i3": 2017, "x1e": 2017,
+	"c5": 2017, "m5": 2017, "p3": 2017, "c5d": 2018, "m5a": 2018, "m5d": 2018,
+	"r5": 2018, "r5a": 2018, "r5d": 2018, "t3": 2018, "t3a": 2019, "a1": 2018, "z1d": 2018,
+	"c5n": 2018, "g4dn": 2019, "i3en": 2019, "inf1": 2019,
+	"c6g": 2020, "m6g": 2020, "r6g": 2020, "t4g": 2020, "p4d": 2020, "d3": 2020, "c5a": 2020,
+	"c6gn": 2021, "m6i": 2021, "c6i": 2021, "r6i": 2021, "m6a": 2021, "g5": 2021, "x2gd": 2021,
+	"c6a": 2022, "c7g": 2022, "i4i": 2022, "m7g": 2023, "r7g": 2023, "c7i": 2023, "m7i": 2023,
+}
+
+// instanceFamily instance family of instance type, e.g. "m5d" for "m5d.large"
+func instanceFamily(instance string) string {
+	return strings.SplitN(instance, ".", 2)[0] //nolint:gomnd
+}
+
+// InstanceGeneration get instance type generation: current or previous
+func InstanceGeneration(instance string) string {
+	if previousGenerationFamilies[instanceFamily(instance)] {
+		return GenerationPrevious
+	}
+
+	return GenerationCurrent
+}
+
+// InstanceLaunchYear get launch year of instance type family; 0 if unknown
+func InstanceLaunchYear(instance string) int {
+	return familyLaunchYear[instanceFamily(instance)]
+}
@@ -0,0 +1,43 @@
+package spot
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInstanceGeneration(t *testing.T) {
+	tests := []struct {
+		instance   string
+		generation string
+		launchYear int
+	}{
+		{instance: "m5.large", generation: GenerationCurrent, launchYear: 2017},
+		{instance: "m4.xlarge", generation: GenerationPrevious, launchYear: 2015},
+		{instance: "g3s.xlarge", generation: GenerationPrevious, launchYear: 0},
+		{instance: "m5dn.large", generation: GenerationCurrent, launchYear: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instance, func(t *testing.T) {
+			if got := InstanceGeneration(tt.instance); got != tt.generation {
+				t.Errorf("InstanceGeneration() = %v, want %v", got, tt.generation)
+			}
+			if got := InstanceLaunchYear(tt.instance); got != tt.launchYear {
+				t.Errorf("InstanceLaunchYear() = %v, want %v", got, tt.launchYear)
+			}
+		})
+	}
+}
+
+func TestGetSpotSavings_withCurrentGeneration(t *testing.T) {
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^(m4|m5)\\.", "linux", 0, 0, 0, SortByRange, false,
+		WithCurrentGeneration())
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	for _, advice := range got {
+		if advice.Generation != GenerationCurrent {
+			t.Errorf("GetSpotSavings() previous generation instance %v in results", advice.Instance)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package spot
+
+import "sort"
+
+// Heatmap mean interruption frequency of instance families (rows) by region (columns)
+type Heatmap struct {
+	Families []string    `json:"families"`
+	Regions  []string    `json:"regions"`
+	Cells    [][]float64 `json:"cells"` // mean interruption range midpoint (percent), -1 if family is not available in region
+}
+
+// NewHeatmap aggregate advices into instance family by region heatmap (families and regions sorted)
+func NewHeatmap(advices []Advice) *Heatmap {
+	type cell struct {
+		sum   float64
+		count int
+	}
+
+	cells := make(map[string]map[string]*cell)
+	regions := make(map[string]bool)
+
+	for i := range advices {
+		family, region := instanceFamily(advices[i].Instance), advices[i].Region
+		if cells[family] == nil {
+			cells[family] = make(map[string]*cell)
+		}
+
+		if cells[family][region] == nil {
+			cells[family][region] = &cell{}
+		}
+
+		cells[family][region].sum += advices[i].Range.Mid()
+		cells[family][region].count++
+		regions[region] = true
+	}
+
+	h := &Heatmap{}
+
+	for family := range cells {
+		h.Families = append(h.Families, family)
+	}
+
+	for region := range regions {
+		h.Regions = append(h.Regions, region)
+	}
+
+	sort.Strings(h.Families)
+	sort.Strings(h.Regions)
+
+	for _, family := range h.Families {
+		row := make([]float64, len(h.Regions))
+
+		for i, region := range h.Regions {
+			row[i] = -1
+			if c, ok := cells[family][region]; ok {
+				row[i] = c.sum / float64(c.count)
+			}
+		}
+
+		h.Cells = append(h.Cells, row)
+	}
+
+	return h
+}
@@ -0,0 +1,28 @@
+package spot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewHeatmap(t *testing.T) {
+	low := Range{Label: "<5%", Min: 0, Max: 5}
+	high := Range{Label: ">20%", Min: 23, Max: 100}
+
+	advices := []Advice{
+		{Region: "us-east-1", Instance: "m5.large", Range: low},
+		{Region: "us-east-1", Instance: "m5.xlarge", Range: high},
+		{Region: "eu-west-1", Instance: "m5.large", Range: high},
+		{Region: "eu-west-1", Instance: "c5.large", Range: low},
+	}
+
+	want := &Heatmap{
+		Families: []string{"c5", "m5"},
+		Regions:  []string{"eu-west-1", "us-east-1"},
+		Cells:    [][]float64{{2.5, -1}, {61.5, 32}},
+	}
+
+	if got := NewHeatmap(advices); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewHeatmap() = %+v, want %+v", got, want)
+	}
+}
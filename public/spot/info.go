@@ -1,8 +1,12 @@
 package spot
 
 import (
+	"context"
 	_ "embed" //nolint:gci
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
 	"net/http"
 	"regexp"
 	"sort"
@@ -19,6 +23,12 @@ var (
 	embeddedSpotData string
 	// parsed json raw data
 	data *advisorData
+	// error returned by lazy data load
+	dataErr error
+	// timeout for fetching data feeds
+	fetchTimeout = defaultFetchTimeout
+	// fail instead of silent fallbacks (embedded data, missing prices)
+	strict bool
 	// min ranges
 	minRange = map[int]int{5: 0, 11: 6, 16: 12, 22: 17, 100: 23} //nolint:gomnd
 )
@@ -33,10 +43,16 @@ const (
 	// SortByPrice sort by spot price
 	SortByPrice = iota
 	// SortByRegion sort by AWS region name
-	SortByRegion       = iota
-	spotAdvisorJSONURL = "https://spot-bid-advisor.s3.amazonaws.com/spot-advisor-data.json"
+	SortByRegion = iota
+	// SortByAdjustedPrice sort by spot price adjusted for interruption frequency, see Advice.AdjustedPrice
+	SortByAdjustedPrice = iota
+	spotAdvisorJSONURL  = "https://spot-bid-advisor.s3.amazonaws.com/spot-advisor-data.json"
+	defaultFetchTimeout = 10 * time.Second
 )
 
+// HoursPerMonth average number of hours in a month, as used by AWS pricing
+const HoursPerMonth = 730
+
 type interruptionRange struct {
 	Label string `json:"label"`
 	Index int    `json:"index"`
@@ -65,6 +81,8 @@ type advisorData struct {
 	InstanceTypes map[string]instanceType `json:"instance_types"` //nolint:tagliatelle
 	Regions       map[string]osTypes      `json:"spot_advisor"`   //nolint:tagliatelle
 	Embedded      bool                    // true if loaded from embedded copy
+	checksum      string                  // SHA-256 checksum of loaded data
+	loadedAt      time.Time
 }
 
 //---- public types
@@ -76,18 +94,94 @@ type Range struct {
 	Max   int    `json:"max"`
 }
 
+// Mid middle of interruption range (percent)
+func (r Range) Mid() float64 {
+	return float64(r.Min+r.Max) / 2 //nolint:gomnd
+}
+
+// Reliability reliability score 0-100: 100 minus interruption range midpoint, rounded; higher is better
+func (r Range) Reliability() int {
+	return int(math.Round(100 - r.Mid())) //nolint:gomnd
+}
+
 // TypeInfo instance type details: vCPU cores, memory, cam  run in EMR
 type TypeInfo instanceType
 
 // Advice - spot price advice: interruption range and savings
 type Advice struct {
-	Region    string
-	Instance  string
-	Range     Range
-	Savings   int
-	Info      TypeInfo
-	Price     float64
-	ZonePrice map[string]float64
+	Region     string
+	Instance   string
+	Range      Range
+	Savings    int
+	Info       TypeInfo
+	Price      float64
+	ZonePrice  map[string]float64
+	Compliance []string `json:"compliance,omitempty"`
+	// interruption range bounds and midpoint (percent), numeric counterparts of Range.Label
+	InterruptionMin int     `json:"interruption_min"` //nolint:tagliatelle
+	InterruptionMax int     `json:"interruption_max"` //nolint:tagliatelle
+	InterruptionMid float64 `json:"interruption_mid"` //nolint:tagliatelle
+	// reliability score 0-100 derived from interruption range, see Range.Reliability
+	Reliability int `json:"reliability"`
+	// instance generation (current/previous) and family launch year (0 if unknown)
+	Generation string `json:"generation"`
+	LaunchYear int    `json:"launch_year,omitempty"` //nolint:tagliatelle
+	// workload tags: efa, sap-certified, neuron, hibernate
+	Tags []string `json:"tags,omitempty"`
+	// data quality flags: price_missing, advisor_only, info_missing, embedded_data
+	Flags []string `json:"flags,omitempty"`
+	// free-form annotations set by enrichers, e.g. "recommended_for": "batch"
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// On-Demand price in USD per hour from AWS Price List (see OnDemandPriceList); 0 if not loaded
+	OnDemand float64 `json:"on_demand_price,omitempty"` //nolint:tagliatelle
+}
+
+// Annotate set advice annotation
+func (a *Advice) Annotate(key, value string) {
+	if a.Annotations == nil {
+		a.Annotations = make(map[string]string)
+	}
+
+	a.Annotations[key] = value
+}
+
+// MonthlySavings savings over On-Demand in USD per month (730 hours): from Price List On-Demand price, if loaded, or
+// derived from spot price and savings percentage
+func (a *Advice) MonthlySavings() float64 {
+	if a.OnDemand > 0 {
+		if a.Price <= 0 || a.Price >= a.OnDemand {
+			return 0
+		}
+
+		return (a.OnDemand - a.Price) * HoursPerMonth
+	}
+
+	if a.Savings <= 0 || a.Savings >= 100 {
+		return 0
+	}
+
+	// on-demand price = spot price / (1 - savings)
+	return a.Price * float64(a.Savings) / float64(100-a.Savings) * HoursPerMonth
+}
+
+// OnDemandPrice On-Demand (and On-Demand Capacity Reservation) price in USD per hour: Price List price, if loaded, or
+// derived from spot price and savings percentage; 0 if unknown
+func (a *Advice) OnDemandPrice() float64 {
+	if a.OnDemand > 0 {
+		return a.OnDemand
+	}
+
+	if a.Savings < 0 || a.Savings >= 100 {
+		return 0
+	}
+
+	return a.Price * 100 / float64(100-a.Savings) //nolint:gomnd
+}
+
+// AdjustedPrice spot price penalized for interruptions: price * (1 + penalty * interruption range midpoint);
+// e.g. penalty 1 makes >20% interruption range (61.5% midpoint) instance 1.615 times more expensive
+func (a *Advice) AdjustedPrice(penalty float64) float64 {
+	return a.Price * (1 + penalty*a.Range.Mid()/100) //nolint:gomnd
 }
 
 // ByRange implements sort.Interface based on the Range.Min field
@@ -125,12 +219,59 @@ func (a ByRegion) Len() int           { return len(a) }
 func (a ByRegion) Less(i, j int) bool { return strings.Compare(a[i].Region, a[j].Region) == -1 }
 func (a ByRegion) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
-func dataLazyLoad(url string, timeout time.Duration, fallbackData string) (*advisorData, error) {
-	var result advisorData
+// byAdjustedPrice implements sort.Interface based on interruption adjusted price
+type byAdjustedPrice struct {
+	advices []Advice
+	penalty float64
+}
+
+func (a byAdjustedPrice) Len() int { return len(a.advices) }
+func (a byAdjustedPrice) Less(i, j int) bool {
+	return a.advices[i].AdjustedPrice(a.penalty) < a.advices[j].AdjustedPrice(a.penalty)
+}
+func (a byAdjustedPrice) Swap(i, j int) { a.advices[i], a.advices[j] = a.advices[j], a.advices[i] }
+
+// Feed AWS data feed used by spotinfo
+type Feed struct {
+	Name string
+	URL  string
+}
+
+// DataFeeds list of AWS data feeds fetched on the first query (embedded copies are used as fallback)
+func DataFeeds() []Feed {
+	return []Feed{
+		{Name: "spot advisor", URL: spotAdvisorJSONURL},
+		{Name: "spot pricing", URL: spotPriceJsURL},
+	}
+}
+
+// SetFetchTimeout set timeout for fetching data feeds; must be called before the first query
+func SetFetchTimeout(timeout time.Duration) {
+	fetchTimeout = timeout
+}
+
+// SetStrict fail instead of falling back to embedded data when a data feed cannot be loaded, and instead of
+// returning zero price for instance types without (or with unparseable) pricing data; must be called before the first query
+func SetStrict(enabled bool) {
+	strict = enabled
+}
+
+func dataLazyLoad(ctx context.Context, url string, timeout time.Duration, fallbackData string) (*advisorData, error) {
+	var (
+		result advisorData
+		req    *http.Request
+		resp   *http.Response
+		body   []byte
+	)
 	// try to load new data
-	client := &http.Client{Timeout: timeout}
+	client := newHTTPClient(timeout)
 
-	resp, err := client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		goto fallback
+	}
+
+	resp, err = client.Do(req)
 	if err != nil {
 		goto fallback
 	}
@@ -140,18 +281,36 @@ func dataLazyLoad(url string, timeout time.Duration, fallbackData string) (*advi
 	}()
 
 	if resp.StatusCode != http.StatusOK {
+		err = errors.Errorf("unexpected response status: %s", resp.Status)
+
 		goto fallback
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	body, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
 		goto fallback
 	}
 
+	// reject data not matching expected checksum
+	if err = verifyChecksum(url, body); err != nil {
+		goto fallback
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		goto fallback
+	}
+
+	result.checksum = sha256sum(body)
+
 	return &result, nil
 
 	// fallback to embedded load
 fallback:
+	if strict {
+		return nil, errors.Wrapf(err, "failed to load %s (strict mode)", url)
+	}
+
 	err = json.Unmarshal([]byte(fallbackData), &result)
 
 	if err != nil {
@@ -160,22 +319,53 @@ fallback:
 
 	// set embedded loaded flag true
 	result.Embedded = true
+	result.checksum = sha256sum([]byte(fallbackData))
 
 	return &result, nil
 }
 
-// GetSpotSavings get spot saving advices
-//nolint:gocognit,gocyclo
-func GetSpotSavings(regions []string, pattern, instanceOS string, cpu, memory int, price float64, sortBy int, sortDesc bool) ([]Advice, error) {
-	var err error
-
+func getAdvisorData(ctx context.Context) (*advisorData, error) {
 	loadDataOnce.Do(func() {
-		const timeout = 10
-		data, err = dataLazyLoad(spotAdvisorJSONURL, timeout*time.Second, embeddedSpotData)
+		data, dataErr = dataLazyLoad(ctx, spotAdvisorJSONURL, fetchTimeout, embeddedSpotData)
+		if dataErr == nil {
+			data.loadedAt = time.Now()
+		}
 	})
 
+	if dataErr != nil {
+		return nil, errors.Wrap(dataErr, "failed to load spot data")
+	}
+
+	return data, nil
+}
+
+// Regions list AWS regions available in spot advisor data (sorted)
+func Regions(ctx context.Context) ([]string, error) {
+	return regionsWithPrefix(ctx, []string{""})
+}
+
+// GetSpotSavings get spot saving advices
+func GetSpotSavings(regions []string, pattern, instanceOS string, cpu, memory int, price float64, sortBy int, sortDesc bool, opts ...Option) ([]Advice, error) {
+	return GetSpotSavingsContext(context.Background(), regions, pattern, instanceOS, cpu, memory, price, sortBy, sortDesc, opts...)
+}
+
+// GetSpotSavingsContext get spot saving advices
+// if context is done in the middle of query, partial results are returned together with the context error
+//nolint:gocognit,gocyclo,funlen
+func GetSpotSavingsContext(ctx context.Context, regions []string, pattern, instanceOS string, cpu, memory int, price float64, sortBy int, sortDesc bool, opts ...Option) ([]Advice, error) {
+	o := newOptions(opts)
+
+	if err := validateCompliance(o.compliance); err != nil {
+		return nil, err
+	}
+
+	instanceOS, err := ParseOS(instanceOS)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to load spot data")
+		return nil, err
+	}
+
+	if _, err := getAdvisorData(ctx); err != nil {
+		return nil, err
 	}
 
 	// special case: "all" regions (slice with single element)
@@ -190,23 +380,45 @@ func GetSpotSavings(regions []string, pattern, instanceOS string, cpu, memory in
 	// get advices for specified regions
 	var result []Advice
 
-	for _, region := range regions {
+	ctxErr := ctx.Err()
+
+regionsLoop:
+	for i, region := range regions {
+		if ctxErr != nil {
+			break
+		}
+
+		// filter by region compliance
+		if len(o.compliance) > 0 && !matchCompliance(region, o.compliance) {
+			o.exclude(region, "", "compliance", fmt.Sprintf("region is not %s", strings.Join(o.compliance, "/")))
+			o.report(region, i+1, len(regions), 0)
+
+			continue
+		}
+
+		first := len(result)
+
 		r, ok := data.Regions[region]
 		if !ok {
-			return nil, errors.Errorf("no spot price for region %s", region)
+			if err = o.regionFailed(region, errors.New("no spot advisor data")); err != nil {
+				return nil, err
+			}
+
+			o.report(region, i+1, len(regions), 0)
+
+			continue
 		}
 
-		var advices map[string]advice
-		if strings.EqualFold("windows", instanceOS) {
+		advices := r.Linux
+		if instanceOS == OSWindows {
 			advices = r.Windows
-		} else if strings.EqualFold("linux", instanceOS) {
-			advices = r.Linux
-		} else {
-			return nil, errors.New("invalid instance OS, must be windows/linux")
 		}
 
 		// construct advices result
 		for instance, adv := range advices {
+			if ctxErr = ctx.Err(); ctxErr != nil {
+				break regionsLoop
+			}
 			// match instance type name
 			matched, err := regexp.MatchString(pattern, instance)
 			if err != nil {
@@ -216,16 +428,51 @@ func GetSpotSavings(regions []string, pattern, instanceOS string, cpu, memory in
 			if !matched { // skip not matched
 				continue
 			}
+
+			if o.current && InstanceGeneration(instance) == GenerationPrevious {
+				o.exclude(region, instance, "generation", "previous generation instance type")
+
+				continue
+			}
+
+			info, infoFound := data.InstanceTypes[instance]
+
+			tags := instanceTags(instance, info.RAM)
+			if missing := missingTags(tags, o.tags); len(missing) > 0 {
+				o.exclude(region, instance, "tags", fmt.Sprintf("not %s", strings.Join(missing, "/")))
+
+				continue
+			}
 			// filter by min vCPU and memory
-			info := data.InstanceTypes[instance]
-			if (cpu != 0 && info.Cores < cpu) || (memory != 0 && info.RAM < float32(memory)) {
+			if cpu != 0 && info.Cores < cpu {
+				o.exclude(region, instance, "cpu", fmt.Sprintf("%d vCPU < %d", info.Cores, cpu))
+
+				continue
+			}
+
+			if memory != 0 && info.RAM < float32(memory) {
+				o.exclude(region, instance, "memory", fmt.Sprintf("%vGiB < %dGiB", info.RAM, memory))
+
 				continue
 			}
 			// get price details
-			spotPrice, err := getSpotInstancePrice(instance, region, instanceOS, false)
+			spotPrice, err := getSpotInstancePrice(ctx, instance, region, instanceOS, false)
+			if err != nil && strict {
+				if err = o.regionFailed(region, err); err != nil {
+					return nil, err
+				}
+
+				result = result[:first]
+				o.report(region, i+1, len(regions), 0)
+
+				continue regionsLoop
+			}
+
 			if err == nil {
 				// filter by max price
 				if price != 0 && spotPrice > price {
+					o.exclude(region, instance, "price", fmt.Sprintf("%v USD/hour > %v", spotPrice, price))
+
 					continue
 				}
 			}
@@ -237,15 +484,46 @@ func GetSpotSavings(regions []string, pattern, instanceOS string, cpu, memory in
 				Min:   minRange[data.Ranges[adv.Range].Max],
 			}
 
-			result = append(result, Advice{
-				Region:   region,
-				Instance: instance,
-				Range:    rng,
-				Savings:  adv.Savings,
-				Info:     TypeInfo(info),
-				Price:    spotPrice,
-			})
+			advice := Advice{
+				Region:     region,
+				Instance:   instance,
+				Range:      rng,
+				Savings:    adv.Savings,
+				Info:       TypeInfo(info),
+				Price:      spotPrice,
+				Compliance: RegionCompliance(region),
+
+				InterruptionMin: rng.Min,
+				InterruptionMax: rng.Max,
+				InterruptionMid: rng.Mid(),
+				Reliability:     rng.Reliability(),
+				Generation:      InstanceGeneration(instance),
+				LaunchYear:      InstanceLaunchYear(instance),
+				Tags:            tags,
+				Flags:           qualityFlags(ctx, region, instanceOS, infoFound, err == nil),
+			}
+
+			if err = o.enrich(ctx, &advice); err != nil {
+				if err = o.regionFailed(region, errors.Wrapf(err, "failed to enrich %s advice", instance)); err != nil {
+					return nil, err
+				}
+
+				result = result[:first]
+				o.report(region, i+1, len(regions), 0)
+
+				continue regionsLoop
+			}
+
+			if !o.match(&advice) {
+				o.exclude(region, instance, "custom", "rejected by custom filter")
+
+				continue
+			}
+
+			result = append(result, advice)
 		}
+
+		o.report(region, i+1, len(regions), len(result)-first)
 	}
 
 	// sort results by - range (default)
@@ -262,6 +540,8 @@ func GetSpotSavings(regions []string, pattern, instanceOS string, cpu, memory in
 		data = ByPrice(result)
 	case SortByRegion:
 		data = ByRegion(result)
+	case SortByAdjustedPrice:
+		data = byAdjustedPrice{advices: result, penalty: o.penalty}
 	default:
 		data = ByRange(result)
 	}
@@ -272,5 +552,9 @@ func GetSpotSavings(regions []string, pattern, instanceOS string, cpu, memory in
 
 	sort.Sort(data)
 
+	if ctxErr != nil {
+		return result, errors.Wrap(ctxErr, "partial results")
+	}
+
 	return result, nil
 }
@@ -1,6 +1,12 @@
 package spot
 
 import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"sort"
 	"strings"
@@ -53,7 +59,7 @@ func Test_dataLazyLoad(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := dataLazyLoad(tt.args.url, tt.args.timeout, tt.args.fallback)
+			got, err := dataLazyLoad(context.Background(), tt.args.url, tt.args.timeout, tt.args.fallback)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("dataLazyLoad() error = %v, wantErr %v", err, tt.wantErr)
 				return //nolint:nlreturn
@@ -70,6 +76,28 @@ func Test_dataLazyLoad(t *testing.T) {
 	}
 }
 
+func Test_dataLazyLoad_strict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	defer SetStrict(false)
+
+	SetStrict(true)
+
+	if _, err := dataLazyLoad(context.Background(), server.URL, 1*time.Second, embeddedSpotData); err == nil {
+		t.Error("dataLazyLoad() strict mode: want error on unavailable feed, got embedded data")
+	}
+
+	if _, err := pricingLazyLoad(context.Background(), server.URL, 1*time.Second, embeddedPriceData, false); err == nil {
+		t.Error("pricingLazyLoad() strict mode: want error on unavailable feed, got embedded data")
+	}
+
+	if got, err := pricingLazyLoad(context.Background(), server.URL, 1*time.Second, embeddedPriceData, true); err != nil || !got.Embedded {
+		t.Errorf("pricingLazyLoad() strict mode, embedded requested: error = %v, want embedded data", err)
+	}
+}
+
 //nolint:funlen,gocognit,gocyclo
 func TestGetSpotSavings(t *testing.T) { //nolint:cyclop
 	type args struct {
@@ -163,6 +191,10 @@ func TestGetSpotSavings(t *testing.T) { //nolint:cyclop
 					if tt.want.maxPrice != 0 && advice.Price > tt.want.maxPrice {
 						t.Errorf("GetSpotSavings() advice.Price = %v > max %v", advice.Price, tt.want.maxPrice)
 					}
+					if advice.InterruptionMin != advice.Range.Min || advice.InterruptionMax != advice.Range.Max ||
+						advice.InterruptionMid != advice.Range.Mid() || advice.Reliability != advice.Range.Reliability() {
+						t.Errorf("GetSpotSavings() advice interruption fields do not match range %+v", advice.Range)
+					}
 				}
 				// validate sort
 				var compareFunc func(i, j int) bool
@@ -215,3 +247,164 @@ func TestGetSpotSavings(t *testing.T) { //nolint:cyclop
 		})
 	}
 }
+
+func TestRange_Mid(t *testing.T) {
+	tests := []struct {
+		name string
+		rng  Range
+		want float64
+	}{
+		{name: "<5%", rng: Range{Label: "<5%", Min: 0, Max: 5}, want: 2.5},
+		{name: "5-10%", rng: Range{Label: "5-10%", Min: 6, Max: 11}, want: 8.5},
+		{name: ">20%", rng: Range{Label: ">20%", Min: 23, Max: 100}, want: 61.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rng.Mid(); got != tt.want {
+				t.Errorf("Range.Mid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Reliability(t *testing.T) {
+	tests := []struct {
+		name string
+		rng  Range
+		want int
+	}{
+		{name: "<5%", rng: Range{Label: "<5%", Min: 0, Max: 5}, want: 98},
+		{name: "10-15%", rng: Range{Label: "10-15%", Min: 12, Max: 16}, want: 86},
+		{name: ">20%", rng: Range{Label: ">20%", Min: 23, Max: 100}, want: 39},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rng.Reliability(); got != tt.want {
+				t.Errorf("Range.Reliability() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdvice_MonthlySavings(t *testing.T) {
+	tests := []struct {
+		name   string
+		advice Advice
+		want   float64
+	}{
+		{name: "50% savings", advice: Advice{Price: 0.1, Savings: 50}, want: 73},
+		{name: "75% savings", advice: Advice{Price: 0.1, Savings: 75}, want: 219},
+		{name: "no savings", advice: Advice{Price: 0.1, Savings: 0}, want: 0},
+		{name: "unknown price", advice: Advice{Savings: 60}, want: 0},
+		{name: "price list On-Demand price", advice: Advice{Price: 0.1, Savings: 50, OnDemand: 0.25}, want: 109.5},
+		{name: "spot above price list price", advice: Advice{Price: 0.3, Savings: 50, OnDemand: 0.25}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.advice.MonthlySavings(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Advice.MonthlySavings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdvice_Annotate(t *testing.T) {
+	var advice Advice
+
+	advice.Annotate("recommended_for", "batch")
+	advice.Annotate("team", "ml")
+	advice.Annotate("recommended_for", "ci")
+
+	want := map[string]string{"recommended_for": "ci", "team": "ml"}
+	if !reflect.DeepEqual(advice.Annotations, want) {
+		t.Errorf("Advice.Annotations = %v, want %v", advice.Annotations, want)
+	}
+}
+
+func TestAdvice_OnDemandPrice(t *testing.T) {
+	tests := []struct {
+		name   string
+		advice Advice
+		want   float64
+	}{
+		{name: "50% savings", advice: Advice{Price: 0.1, Savings: 50}, want: 0.2},
+		{name: "75% savings", advice: Advice{Price: 0.1, Savings: 75}, want: 0.4},
+		{name: "no savings", advice: Advice{Price: 0.1, Savings: 0}, want: 0.1},
+		{name: "invalid savings", advice: Advice{Price: 0.1, Savings: 100}, want: 0},
+		{name: "unknown price", advice: Advice{Savings: 60}, want: 0},
+		{name: "price list On-Demand price", advice: Advice{Price: 0.1, Savings: 50, OnDemand: 0.25}, want: 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.advice.OnDemandPrice(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Advice.OnDemandPrice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdvice_AdjustedPrice(t *testing.T) {
+	advice := Advice{Price: 0.1, Range: Range{Label: ">20%", Min: 23, Max: 100}}
+
+	tests := []struct {
+		name    string
+		penalty float64
+		want    float64
+	}{
+		{name: "no penalty", penalty: 0, want: 0.1},
+		{name: "default penalty", penalty: 1, want: 0.1615},
+		{name: "double penalty", penalty: 2, want: 0.223},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := advice.AdjustedPrice(tt.penalty); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Advice.AdjustedPrice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSpotSavings_sortByAdjustedPrice(t *testing.T) {
+	const penalty = 2
+
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5", "linux", 0, 0, 0, SortByAdjustedPrice, false,
+		WithInterruptionPenalty(penalty))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	if !sort.SliceIsSorted(got, func(i, j int) bool { return got[i].AdjustedPrice(penalty) < got[j].AdjustedPrice(penalty) }) {
+		t.Error("GetSpotSavings() advices are not sorted by adjusted price")
+	}
+}
+
+func TestRegions(t *testing.T) {
+	got, err := Regions(context.Background())
+	if err != nil {
+		t.Fatalf("Regions() error = %v", err)
+	}
+
+	if !sort.StringsAreSorted(got) {
+		t.Error("Regions() regions are not sorted")
+	}
+
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		if i := sort.SearchStrings(got, region); i == len(got) || got[i] != region {
+			t.Errorf("Regions() missing %s", region)
+		}
+	}
+}
+
+func TestGetSpotSavings_partialResults(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	got, err := GetSpotSavingsContext(ctx, []string{"us-east-1"}, "", "linux", 0, 0, 0, SortByRange, false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetSpotSavings() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("GetSpotSavings() got %v advices, want none", len(got))
+	}
+}
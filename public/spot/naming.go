@@ -0,0 +1,143 @@
+package spot
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// InstanceName instance type name parts, e.g. m7gd.4xlarge: series m, generation 7, attributes g (Graviton) and d
+// (local NVMe storage), size 4xlarge
+type InstanceName struct {
+	Instance   string     `json:"instance"`
+	Family     string     `json:"family"`
+	Series     NamePart   `json:"series"`
+	Generation int        `json:"generation"`
+	Attributes []NamePart `json:"attributes,omitempty"`
+	Options    []NamePart `json:"options,omitempty"`
+	Size       string     `json:"size"`
+	Metal      bool       `json:"metal"`
+	Lifecycle  string     `json:"lifecycle"`             // current or previous generation family
+	LaunchYear int        `json:"launch_year,omitempty"` //nolint:tagliatelle
+}
+
+// NamePart instance type name part and its meaning
+type NamePart struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// instance series (family prefix letters) meanings
+var instanceSeries = map[string]string{
+	"a":   "general purpose (AWS Graviton, first generation)",
+	"c":   "compute optimized",
+	"d":   "dense HDD storage",
+	"dl":  "deep learning accelerators",
+	"f":   "FPGA accelerators",
+	"g":   "graphics accelerators (GPU)",
+	"h":   "HDD storage",
+	"hpc": "high performance computing",
+	"i":   "storage optimized (NVMe SSD)",
+	"im":  "storage optimized (NVMe SSD, memory balanced)",
+	"inf": "AWS Inferentia machine learning inference",
+	"is":  "storage optimized (NVMe SSD, storage dense)",
+	"m":   "general purpose",
+	"mac": "macOS on Mac hardware",
+	"p":   "GPU accelerated computing",
+	"r":   "memory optimized",
+	"t":   "burstable performance",
+	"trn": "AWS Trainium machine learning training",
+	"u":   "high memory",
+	"vt":  "video transcoding",
+	"x":   "memory intensive",
+	"z":   "high frequency and memory",
+}
+
+// instance attribute letters (after generation) meanings
+var instanceAttributes = map[string]string{
+	"a": "AMD processors",
+	"b": "block storage (EBS) optimized",
+	"d": "local NVMe instance storage",
+	"e": "extra memory or storage",
+	"g": "AWS Graviton processors",
+	"i": "Intel processors",
+	"n": "network optimized",
+	"q": "Qualcomm inference accelerators",
+	"z": "high frequency",
+}
+
+// instance family options (after dash) meanings
+var instanceOptions = map[string]string{
+	"flex": "flex: lower price for workloads not using full CPU all the time",
+}
+
+// family name: series letters (or u-<memory>tb for high memory), generation, attribute letters and dash option
+var familyName = regexp.MustCompile(`^(u-(\d+)tb|[a-z]+?)(\d+)([a-z]*)(?:-([a-z0-9]+))?$`)
+
+// ParseInstanceName parse instance type name (e.g. m7gd.4xlarge) and explain its parts
+func ParseInstanceName(instance string) (*InstanceName, error) {
+	family, size := instanceFamily(instance), ""
+	if i := strings.Index(instance, "."); i > 0 {
+		size = instance[i+1:]
+	}
+
+	match := familyName.FindStringSubmatch(family)
+	if match == nil || size == "" {
+		return nil, errors.Errorf("invalid instance type %q, expected <family>.<size>, e.g. m5.large", instance)
+	}
+
+	series := match[1]
+	description, ok := instanceSeries[series]
+
+	if memory := match[2]; memory != "" {
+		series, ok = "u", true
+		description = instanceSeries["u"] + " (" + memory + " TiB)"
+	}
+
+	if !ok {
+		description = "unknown series"
+	}
+
+	generation, err := strconv.Atoi(match[3])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid generation of instance type %q", instance)
+	}
+
+	name := InstanceName{
+		Instance:   instance,
+		Family:     family,
+		Series:     NamePart{Code: series, Description: description},
+		Generation: generation,
+		Size:       size,
+		Metal:      strings.HasPrefix(size, "metal"),
+		Lifecycle:  InstanceGeneration(instance),
+		LaunchYear: InstanceLaunchYear(instance),
+	}
+
+	for _, code := range match[4] {
+		description, ok := instanceAttributes[string(code)]
+		if !ok {
+			description = "unknown attribute"
+		}
+
+		name.Attributes = append(name.Attributes, NamePart{Code: string(code), Description: description})
+	}
+
+	if option := match[5]; option != "" {
+		description, ok := instanceOptions[option]
+		if memory := strings.TrimSuffix(option, "tb"); !ok && memory != option {
+			_, err = strconv.Atoi(memory)
+			description, ok = memory+" TiB memory", err == nil
+		}
+
+		if !ok {
+			description = "variant"
+		}
+
+		name.Options = append(name.Options, NamePart{Code: option, Description: description})
+	}
+
+	return &name, nil
+}
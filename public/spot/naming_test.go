@@ -0,0 +1,85 @@
+package spot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseInstanceName(t *testing.T) {
+	tests := []struct {
+		instance   string
+		series     string
+		generation int
+		attributes []string
+		options    []string
+		size       string
+		metal      bool
+		wantErr    bool
+	}{
+		{instance: "m7gd.4xlarge", series: "m", generation: 7, attributes: []string{"g", "d"}, size: "4xlarge"},
+		{instance: "m5.large", series: "m", generation: 5, size: "large"},
+		{instance: "x2iedn.metal", series: "x", generation: 2, attributes: []string{"i", "e", "d", "n"}, size: "metal", metal: true},
+		{instance: "inf2.xlarge", series: "inf", generation: 2, size: "xlarge"},
+		{instance: "is4gen.medium", series: "is", generation: 4, attributes: []string{"g", "e", "n"}, size: "medium"},
+		{instance: "m7i-flex.large", series: "m", generation: 7, attributes: []string{"i"}, options: []string{"flex"}, size: "large"},
+		{instance: "mac2-m2pro.metal", series: "mac", generation: 2, options: []string{"m2pro"}, size: "metal", metal: true},
+		{instance: "u-6tb1.metal", series: "u", generation: 1, size: "metal", metal: true},
+		{instance: "u7i-12tb.224xlarge", series: "u", generation: 7, attributes: []string{"i"}, options: []string{"12tb"}, size: "224xlarge"},
+		{instance: "m5", wantErr: true},
+		{instance: "large.m5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instance, func(t *testing.T) {
+			got, err := ParseInstanceName(tt.instance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInstanceName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Series.Code != tt.series || got.Generation != tt.generation || got.Size != tt.size || got.Metal != tt.metal {
+				t.Errorf("ParseInstanceName() = %+v", got)
+			}
+			if codes := partCodes(got.Attributes); !reflect.DeepEqual(codes, tt.attributes) {
+				t.Errorf("ParseInstanceName() attributes = %v, want %v", codes, tt.attributes)
+			}
+			if codes := partCodes(got.Options); !reflect.DeepEqual(codes, tt.options) {
+				t.Errorf("ParseInstanceName() options = %v, want %v", codes, tt.options)
+			}
+			for _, part := range append(append([]NamePart{got.Series}, got.Attributes...), got.Options...) {
+				if part.Description == "" {
+					t.Errorf("ParseInstanceName() %q has no description", part.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestParseInstanceName_descriptions(t *testing.T) {
+	got, err := ParseInstanceName("u7i-12tb.224xlarge")
+	if err != nil {
+		t.Fatalf("ParseInstanceName() error = %v", err)
+	}
+
+	if want := "12 TiB memory"; got.Options[0].Description != want {
+		t.Errorf("ParseInstanceName() option = %v, want %v", got.Options[0].Description, want)
+	}
+
+	got, err = ParseInstanceName("m4.large")
+	if err != nil {
+		t.Fatalf("ParseInstanceName() error = %v", err)
+	}
+
+	if got.Lifecycle != GenerationPrevious || got.LaunchYear != 2015 {
+		t.Errorf("ParseInstanceName() lifecycle = %v, launch year = %v, want previous, 2015", got.Lifecycle, got.LaunchYear)
+	}
+}
+
+func partCodes(parts []NamePart) []string {
+	var codes []string
+	for _, p := range parts {
+		codes = append(codes, p.Code)
+	}
+
+	return codes
+}
@@ -0,0 +1,306 @@
+package spot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AWS Price List Bulk API: regional EC2 offer file
+	onDemandOfferURL = "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws/AmazonEC2/current/%s/index.json"
+	// offer files are hundreds of MB
+	onDemandFetchTimeout = 5 * time.Minute
+	onDemandCacheTTL     = 7 * 24 * time.Hour
+)
+
+// operating system names of Price List products
+var offerOS = map[string]string{
+	OSLinux:   "Linux",
+	OSWindows: "Windows",
+}
+
+// offerProduct product of EC2 offer file, only fields selecting On-Demand instance prices
+type offerProduct struct {
+	Attributes struct {
+		InstanceType    string `json:"instanceType"`
+		OperatingSystem string `json:"operatingSystem"`
+		Tenancy         string `json:"tenancy"`
+		PreInstalledSw  string `json:"preInstalledSw"`
+		CapacityStatus  string `json:"capacitystatus"`
+		LicenseModel    string `json:"licenseModel"`
+	} `json:"attributes"`
+}
+
+// offerTerm On-Demand term of EC2 offer file product
+type offerTerm struct {
+	PriceDimensions map[string]struct {
+		PricePerUnit struct {
+			USD string `json:"USD"` //nolint:tagliatelle
+		} `json:"pricePerUnit"`
+	} `json:"priceDimensions"`
+}
+
+// ParseOnDemandOffer parse On-Demand hourly prices (USD) of instance types from AWS Price List EC2 offer file: shared
+// tenancy, no pre-installed software, license included; offer file is streamed, products and On-Demand terms are
+// decoded one at a time and other terms are skipped
+func ParseOnDemandOffer(r io.Reader, instanceOS string) (map[string]float64, error) {
+	instanceOS, err := ParseOS(instanceOS)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		dec = json.NewDecoder(r)
+		// instance types and On-Demand prices of matching products by SKU
+		skuTypes    = make(map[string]string)
+		skuPrices   = make(map[string]string)
+		hasProducts bool
+	)
+
+	err = decodeObject(dec, func(key string) error {
+		switch key {
+		case "products":
+			hasProducts = true
+
+			return decodeObject(dec, func(sku string) error {
+				var product offerProduct
+				if err := dec.Decode(&product); err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				a := product.Attributes
+				if a.InstanceType != "" && a.OperatingSystem == offerOS[instanceOS] && a.Tenancy == "Shared" &&
+					a.PreInstalledSw == "NA" && a.CapacityStatus == "Used" && a.LicenseModel == "No License required" {
+					skuTypes[sku] = a.InstanceType
+				}
+
+				return nil
+			})
+		case "terms":
+			return decodeObject(dec, func(termType string) error {
+				if termType != "OnDemand" {
+					return skipValue(dec)
+				}
+
+				return decodeObject(dec, func(sku string) error {
+					// terms usually follow products: skip terms of other products
+					if _, ok := skuTypes[sku]; hasProducts && !ok {
+						return skipValue(dec)
+					}
+
+					var terms map[string]offerTerm
+					if err := dec.Decode(&terms); err != nil {
+						return err //nolint:wrapcheck
+					}
+
+					for _, term := range terms {
+						for _, dimension := range term.PriceDimensions {
+							skuPrices[sku] = dimension.PricePerUnit.USD
+						}
+					}
+
+					return nil
+				})
+			})
+		default:
+			return skipValue(dec)
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse EC2 offer file")
+	}
+
+	prices := make(map[string]float64)
+
+	for sku, instanceType := range skuTypes {
+		usd, ok := skuPrices[sku]
+		if !ok {
+			continue
+		}
+
+		price, err := strconv.ParseFloat(usd, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid On-Demand price of %s", instanceType)
+		}
+
+		prices[instanceType] = price
+	}
+
+	return prices, nil
+}
+
+// decodeObject read JSON object from decoder, calling decode for every key; decode must read key value
+func decodeObject(dec *json.Decoder, decode func(key string) error) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if t != json.Delim('{') {
+		return errors.Errorf("unexpected %v, want object", t)
+	}
+
+	for dec.More() {
+		if t, err = dec.Token(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		key, ok := t.(string)
+		if !ok {
+			return errors.Errorf("unexpected %v, want object key", t)
+		}
+
+		if err = decode(key); err != nil {
+			return err
+		}
+	}
+
+	// closing brace
+	_, err = dec.Token()
+
+	return err //nolint:wrapcheck
+}
+
+// skipValue read next JSON value from decoder token by token, without keeping it in memory
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// OnDemandPrices get On-Demand hourly prices (USD) of instance types in region from AWS Price List Bulk API; prices
+// are cached in cacheDir (unless empty) for 7 days
+func OnDemandPrices(ctx context.Context, region, instanceOS, cacheDir string) (map[string]float64, error) {
+	instanceOS, err := ParseOS(instanceOS)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := filepath.Join(cacheDir, fmt.Sprintf("ondemand-%s-%s.json", region, instanceOS))
+	if cacheDir != "" {
+		if prices, err := readOnDemandCache(cache); err == nil {
+			return prices, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(onDemandOfferURL, region), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create EC2 offer file request")
+	}
+
+	resp, err := newHTTPClient(onDemandFetchTimeout).Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download EC2 offer file of %s", region)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to download EC2 offer file of %s: %s", region, resp.Status)
+	}
+
+	prices, err := ParseOnDemandOffer(resp.Body, instanceOS)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheDir != "" {
+		if err = writeOnDemandCache(cache, prices); err != nil {
+			return nil, err
+		}
+	}
+
+	return prices, nil
+}
+
+// OnDemandPriceList enricher setting Advice.OnDemand from AWS Price List Bulk API prices (see OnDemandPrices), loaded
+// once per region; instance types missing in price list keep derived On-Demand price, or fail in strict mode
+func OnDemandPriceList(instanceOS, cacheDir string) Enricher {
+	regions := make(map[string]map[string]float64)
+
+	return EnricherFunc(func(ctx context.Context, advice *Advice) error {
+		prices, ok := regions[advice.Region]
+		if !ok {
+			var err error
+			if prices, err = OnDemandPrices(ctx, advice.Region, instanceOS, cacheDir); err != nil {
+				return err
+			}
+
+			regions[advice.Region] = prices
+		}
+
+		price, ok := prices[advice.Instance]
+		if !ok && strict {
+			return errors.Errorf("no On-Demand price of %s in %s price list", advice.Instance, advice.Region)
+		}
+
+		advice.OnDemand = price
+
+		return nil
+	})
+}
+
+func readOnDemandCache(path string) (map[string]float64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "no On-Demand prices cache")
+	}
+
+	if time.Since(info.ModTime()) > onDemandCacheTTL {
+		return nil, errors.New("expired On-Demand prices cache")
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read On-Demand prices cache")
+	}
+
+	var prices map[string]float64
+	if err = json.Unmarshal(bytes, &prices); err != nil {
+		return nil, errors.Wrap(err, "failed to parse On-Demand prices cache")
+	}
+
+	return prices, nil
+}
+
+func writeOnDemandCache(path string, prices map[string]float64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gomnd
+		return errors.Wrap(err, "failed to create cache directory")
+	}
+
+	bytes, err := json.Marshal(prices)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode On-Demand prices")
+	}
+
+	if err = ioutil.WriteFile(path, bytes, 0o644); err != nil { //nolint:gosec,gomnd
+		return errors.Wrap(err, "failed to write On-Demand prices cache")
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package spot
+
+import (
+	"context"
+	_ "embed"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+//go:embed test/ec2-offer-test-data.json
+var ec2OfferTestData string
+
+func TestParseOnDemandOffer(t *testing.T) {
+	tests := []struct {
+		name    string
+		os      string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{name: "linux", os: "linux", want: map[string]float64{"m5.large": 0.096, "c5.xlarge": 0.17}},
+		{name: "windows", os: "windows", want: map[string]float64{"m5.large": 0.188}},
+		{name: "unknown OS", os: "reactos", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOnDemandOffer(strings.NewReader(ec2OfferTestData), tt.os)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOnDemandOffer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOnDemandOffer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseOnDemandOffer(strings.NewReader("<html>"), "linux"); err == nil {
+		t.Error("ParseOnDemandOffer() want error on invalid offer file")
+	}
+}
+
+func TestParseOnDemandOffer_stream(t *testing.T) {
+	const product = `{"attributes": {"instanceType": "m5.large", "operatingSystem": "Linux", "tenancy": "Shared",
+		"preInstalledSw": "NA", "capacitystatus": "Used", "licenseModel": "No License required"}}`
+
+	tests := []struct {
+		name    string
+		offer   string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			name: "terms before products",
+			offer: `{"terms": {"Reserved": {"SKU1": {"T1": {"priceDimensions": {"D1": {"pricePerUnit": {"USD": "0.06"}}}}}},
+				"OnDemand": {"SKU1": {"T2": {"priceDimensions": {"D2": {"pricePerUnit": {"USD": "0.096"}}}}}}},
+				"products": {"SKU1": ` + product + `}, "version": "1"}`,
+			want: map[string]float64{"m5.large": 0.096},
+		},
+		{
+			name:  "product without On-Demand term",
+			offer: `{"products": {"SKU1": ` + product + `}, "terms": {"OnDemand": {}}}`,
+			want:  map[string]float64{},
+		},
+		{
+			name: "invalid price",
+			offer: `{"products": {"SKU1": ` + product + `},
+				"terms": {"OnDemand": {"SKU1": {"T1": {"priceDimensions": {"D1": {"pricePerUnit": {"USD": "N/A"}}}}}}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			offer:   `{"products": {"SKU1": ` + product,
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			offer:   `[]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOnDemandOffer(strings.NewReader(tt.offer), "linux")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOnDemandOffer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOnDemandOffer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnDemandPrices_cache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spotinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	want := map[string]float64{"m5.large": 0.096}
+	cache := filepath.Join(dir, "ondemand-us-east-1-linux.json")
+
+	if _, err = readOnDemandCache(cache); err == nil {
+		t.Error("readOnDemandCache() want error on missing cache")
+	}
+
+	if err = writeOnDemandCache(cache, want); err != nil {
+		t.Fatalf("writeOnDemandCache() error = %v", err)
+	}
+
+	got, err := OnDemandPrices(context.Background(), "us-east-1", "Linux", dir)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("OnDemandPrices() = %v, error = %v, want cached %v", got, err, want)
+	}
+
+	expired := time.Now().Add(-onDemandCacheTTL - time.Hour)
+	if err = os.Chtimes(cache, expired, expired); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = readOnDemandCache(cache); err == nil {
+		t.Error("readOnDemandCache() want error on expired cache")
+	}
+
+	// expired cache is not used: offer file is downloaded instead and the (canceled) download fails
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err = OnDemandPrices(ctx, "us-east-1", "linux", dir); err == nil {
+		t.Error("OnDemandPrices() want download error with expired cache")
+	}
+}
+
+func TestOnDemandPriceList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spotinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	if err = writeOnDemandCache(filepath.Join(dir, "ondemand-us-east-1-linux.json"), map[string]float64{"m5.large": 0.096}); err != nil {
+		t.Fatal(err)
+	}
+
+	enricher := OnDemandPriceList("linux", dir)
+
+	advice := Advice{Region: "us-east-1", Instance: "m5.large", Price: 0.04, Savings: 60}
+	if err = enricher.Enrich(context.Background(), &advice); err != nil || advice.OnDemand != 0.096 {
+		t.Errorf("Enrich() OnDemand = %v, error = %v, want 0.096", advice.OnDemand, err)
+	}
+
+	missing := Advice{Region: "us-east-1", Instance: "x9.large", Price: 0.04, Savings: 60}
+	if err = enricher.Enrich(context.Background(), &missing); err != nil || missing.OnDemand != 0 {
+		t.Errorf("Enrich() OnDemand = %v, error = %v, want derived price", missing.OnDemand, err)
+	}
+
+	defer SetStrict(false)
+
+	SetStrict(true)
+
+	if err = enricher.Enrich(context.Background(), &missing); err == nil {
+		t.Error("Enrich() strict mode: want error on instance type missing in price list")
+	}
+}
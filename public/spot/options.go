@@ -0,0 +1,178 @@
+package spot
+
+import (
+	"context"
+	"fmt"
+)
+
+// Option GetSpotSavings query option
+type Option func(*options)
+
+type options struct {
+	compliance []string
+	excluded   func(Exclusion)
+	progress   func(Progress)
+	current    bool
+	tags       []string
+	penalty    float64
+	filters    []func(Advice) bool
+	enrichers  []Enricher
+	failed     func(RegionError)
+}
+
+// Enricher add data to advice (e.g. tags, internal chargeback rates) during GetSpotSavings query
+type Enricher interface {
+	Enrich(ctx context.Context, advice *Advice) error
+}
+
+// EnricherFunc function adapter of Enricher
+type EnricherFunc func(ctx context.Context, advice *Advice) error
+
+// Enrich call f(ctx, advice)
+func (f EnricherFunc) Enrich(ctx context.Context, advice *Advice) error {
+	return f(ctx, advice)
+}
+
+// Exclusion instance (or whole region, when Instance is empty) dropped by a filter
+type Exclusion struct {
+	Region   string
+	Instance string
+	Filter   string // filter name: cpu, memory, price, generation, tags, compliance or custom
+	Reason   string
+}
+
+// RegionError region skipped by a query with WithRegionErrorHandler
+type RegionError struct {
+	Region string
+	Err    error
+}
+
+func (e RegionError) Error() string {
+	return fmt.Sprintf("region %s: %v", e.Region, e.Err)
+}
+
+// Progress query progress, reported after each region is processed
+type Progress struct {
+	Region  string
+	Done    int // processed regions, including this one
+	Total   int // regions to process
+	Matched int // instances in this region that passed all filters
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{penalty: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithCompliance keep only regions tagged with any of the compliance tags (e.g. gdpr, fedramp)
+func WithCompliance(tags ...string) Option {
+	return func(o *options) {
+		o.compliance = append(o.compliance, tags...)
+	}
+}
+
+// WithCurrentGeneration keep only current generation instance types
+func WithCurrentGeneration() Option {
+	return func(o *options) {
+		o.current = true
+	}
+}
+
+// WithTags keep only instance types with all workload tags (e.g. efa, sap-certified)
+func WithTags(tags ...string) Option {
+	return func(o *options) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// WithFilter keep only advices matching predicate (e.g. internal allowlist); applied before sorting, after all
+// other filters; can be used multiple times
+func WithFilter(filter func(Advice) bool) Option {
+	return func(o *options) {
+		o.filters = append(o.filters, filter)
+	}
+}
+
+func (o *options) match(advice *Advice) bool {
+	for _, filter := range o.filters {
+		if !filter(*advice) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithEnricher add enricher; enrichers run in the order added, on advices that passed all built-in filters (after
+// compliance, generation and tags metadata is set), before custom filters (WithFilter) and sorting
+func WithEnricher(enricher Enricher) Option {
+	return func(o *options) {
+		o.enrichers = append(o.enrichers, enricher)
+	}
+}
+
+func (o *options) enrich(ctx context.Context, advice *Advice) error {
+	for _, enricher := range o.enrichers {
+		if err := enricher.Enrich(ctx, advice); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// WithInterruptionPenalty set interruption penalty of SortByAdjustedPrice sort (default 1, 0 sorts by price)
+func WithInterruptionPenalty(penalty float64) Option {
+	return func(o *options) {
+		o.penalty = penalty
+	}
+}
+
+// WithExclusionHandler call handler for every instance matching type pattern, but dropped by other filters
+func WithExclusionHandler(handler func(Exclusion)) Option {
+	return func(o *options) {
+		o.excluded = handler
+	}
+}
+
+func (o *options) exclude(region, instance, filter, reason string) {
+	if o.excluded != nil {
+		o.excluded(Exclusion{Region: region, Instance: instance, Filter: filter, Reason: reason})
+	}
+}
+
+// WithProgress call handler after each processed region, e.g. to report progress of multi-region queries
+func WithProgress(handler func(Progress)) Option {
+	return func(o *options) {
+		o.progress = handler
+	}
+}
+
+func (o *options) report(region string, done, total, matched int) {
+	if o.progress != nil {
+		o.progress(Progress{Region: region, Done: done, Total: total, Matched: matched})
+	}
+}
+
+// WithRegionErrorHandler call handler for a failed region (e.g. no advisor data, no pricing in strict mode, enricher
+// error) and skip it, instead of failing the whole query; results of other regions are returned
+func WithRegionErrorHandler(handler func(RegionError)) Option {
+	return func(o *options) {
+		o.failed = handler
+	}
+}
+
+// regionFailed report region failure to handler, if any; returns RegionError when region failure should fail the query
+func (o *options) regionFailed(region string, err error) error {
+	if o.failed == nil {
+		return RegionError{Region: region, Err: err}
+	}
+
+	o.failed(RegionError{Region: region, Err: err})
+
+	return nil
+}
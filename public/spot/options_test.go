@@ -0,0 +1,185 @@
+package spot
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetSpotSavings_withExclusionHandler(t *testing.T) {
+	const minCPU = 4
+
+	var excluded []Exclusion
+
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5\\.", "linux", minCPU, 0, 0, SortByRange, false,
+		WithExclusionHandler(func(e Exclusion) { excluded = append(excluded, e) }))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	if len(excluded) == 0 {
+		t.Fatal("GetSpotSavings() no exclusions reported for min vCPU filter")
+	}
+
+	for _, e := range excluded {
+		if e.Filter != "cpu" {
+			t.Errorf("GetSpotSavings() exclusion filter = %v, want cpu", e.Filter)
+		}
+
+		for _, advice := range got {
+			if advice.Instance == e.Instance {
+				t.Errorf("GetSpotSavings() excluded instance %v is in results", e.Instance)
+			}
+		}
+	}
+}
+
+func TestGetSpotSavings_withProgress(t *testing.T) {
+	regions := []string{"us-east-1", "eu-west-1", "eu-central-1"}
+
+	var reported []Progress
+
+	got, err := GetSpotSavingsContext(context.Background(), regions, "^m5\\.", "linux", 0, 0, 0, SortByRange, false,
+		WithProgress(func(p Progress) { reported = append(reported, p) }))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	if len(reported) != len(regions) {
+		t.Fatalf("GetSpotSavings() reported progress %d times, want %d", len(reported), len(regions))
+	}
+
+	matched := 0
+
+	for i, p := range reported {
+		if p.Region != regions[i] || p.Done != i+1 || p.Total != len(regions) {
+			t.Errorf("GetSpotSavings() progress #%d = %+v", i, p)
+		}
+
+		matched += p.Matched
+	}
+
+	if matched != len(got) {
+		t.Errorf("GetSpotSavings() reported %d matched instances, got %d results", matched, len(got))
+	}
+}
+
+func TestGetSpotSavings_withFilter(t *testing.T) {
+	allowed := map[string]bool{"m5.large": true, "m5.xlarge": true, "c5.large": true}
+
+	var excluded []Exclusion
+
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5\\.", "linux", 0, 0, 0, SortByInstance, false,
+		WithFilter(func(a Advice) bool { return allowed[a.Instance] }),
+		WithFilter(func(a Advice) bool { return a.Instance != "m5.xlarge" }),
+		WithExclusionHandler(func(e Exclusion) { excluded = append(excluded, e) }))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0].Instance != "m5.large" {
+		t.Errorf("GetSpotSavings() = %v, want only m5.large", got)
+	}
+
+	for _, e := range excluded {
+		if e.Filter != "custom" {
+			t.Errorf("GetSpotSavings() exclusion filter = %v, want custom", e.Filter)
+		}
+	}
+}
+
+func TestGetSpotSavings_withEnricher(t *testing.T) {
+	var order []string
+
+	tag := EnricherFunc(func(ctx context.Context, a *Advice) error {
+		order = append(order, "tag")
+		a.Tags = append(a.Tags, "chargeback")
+
+		return nil
+	})
+	rate := EnricherFunc(func(ctx context.Context, a *Advice) error {
+		order = append(order, "rate")
+		if !hasTag(a.Tags, "chargeback") {
+			t.Errorf("enricher of %v: previous enricher did not run", a.Instance)
+		}
+
+		return nil
+	})
+
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5\\.large$", "linux", 0, 0, 0, SortByRange, false,
+		WithEnricher(tag), WithEnricher(rate),
+		WithFilter(func(a Advice) bool { return hasTag(a.Tags, "chargeback") }))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	if len(got) != 1 || !hasTag(got[0].Tags, "chargeback") {
+		t.Errorf("GetSpotSavings() = %v, want enriched m5.large", got)
+	}
+
+	if len(order) != 2 || order[0] != "tag" || order[1] != "rate" {
+		t.Errorf("GetSpotSavings() enrichers order = %v, want [tag rate]", order)
+	}
+
+	failed := EnricherFunc(func(ctx context.Context, a *Advice) error { return errors.New("rates unavailable") })
+
+	if _, err = GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5\\.large$", "linux", 0, 0, 0, SortByRange, false,
+		WithEnricher(failed)); err == nil {
+		t.Error("GetSpotSavings() want enricher error")
+	}
+}
+
+func TestGetSpotSavings_withRegionErrorHandler(t *testing.T) {
+	var failed []RegionError
+
+	regions := []string{"us-east-1", "xx-nowhere-1", "eu-west-1"}
+
+	got, err := GetSpotSavingsContext(context.Background(), regions, "^m5\\.large$", "linux", 0, 0, 0, SortByRegion, false,
+		WithRegionErrorHandler(func(e RegionError) { failed = append(failed, e) }))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	if len(got) != 2 || got[0].Region != "eu-west-1" || got[1].Region != "us-east-1" {
+		t.Errorf("GetSpotSavings() = %v, want m5.large in eu-west-1 and us-east-1", got)
+	}
+
+	if len(failed) != 1 || failed[0].Region != "xx-nowhere-1" || failed[0].Err == nil {
+		t.Errorf("GetSpotSavings() region errors = %v, want xx-nowhere-1", failed)
+	}
+
+	// enricher failure drops results already collected in the region
+	failed = nil
+
+	fail := EnricherFunc(func(ctx context.Context, a *Advice) error {
+		if a.Region == "eu-west-1" {
+			return errors.New("boom")
+		}
+
+		return nil
+	})
+
+	got, err = GetSpotSavingsContext(context.Background(), []string{"eu-west-1", "us-east-1"}, "^m5\\.", "linux", 0, 0, 0, SortByRegion,
+		false, WithEnricher(fail), WithRegionErrorHandler(func(e RegionError) { failed = append(failed, e) }))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	for _, a := range got {
+		if a.Region != "us-east-1" {
+			t.Errorf("GetSpotSavings() returned advice of failed region %s", a.Region)
+		}
+	}
+
+	if len(failed) != 1 || failed[0].Region != "eu-west-1" {
+		t.Errorf("GetSpotSavings() region errors = %v, want eu-west-1", failed)
+	}
+}
+
+func TestGetSpotSavings_regionErrorWithoutHandler(t *testing.T) {
+	_, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1", "xx-nowhere-1"}, "^m5\\.", "linux", 0, 0, 0,
+		SortByRange, false)
+	if err == nil {
+		t.Error("GetSpotSavings() expected error for unknown region")
+	}
+}
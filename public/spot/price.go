@@ -1,6 +1,7 @@
 package spot
 
 import (
+	"context"
 	_ "embed" //nolint:gci
 	"encoding/json"
 	"io/ioutil"
@@ -19,6 +20,8 @@ var (
 	embeddedPriceData string
 	// spot pricing data
 	spotPrice *spotPriceData
+	// error returned by lazy pricing load
+	spotPriceErr error
 	// aws region map: map between non-standard codes in spot pricing JS and AWS region code
 	awsSpotPricingRegions = map[string]string{
 		"us-east":    "us-east-1",
@@ -28,6 +31,17 @@ var (
 		"apac-syd":   "ap-southeast-2",
 		"apac-tokyo": "ap-northeast-1",
 	}
+	// operating system of spot pricing value columns; other columns are indexed by their own name
+	priceColumnOS = map[string]string{
+		"linux": OSLinux,
+		"mswin": OSWindows,
+	}
+)
+
+// supported instance operating systems
+const (
+	OSLinux   = "linux"
+	OSWindows = "windows"
 )
 
 const (
@@ -37,7 +51,8 @@ const (
 )
 
 type rawPriceData struct {
-	Embedded bool // true if loaded from embedded copy
+	Embedded bool   // true if loaded from embedded copy
+	checksum string // SHA-256 checksum of loaded data
 	Config   struct {
 		Rate         string   `json:"rate"`
 		ValueColumns []string `json:"valueColumns"`
@@ -60,23 +75,26 @@ type rawPriceData struct {
 	} `json:"config"`
 }
 
-type instancePrice struct {
-	linux   float64
-	windows float64
-}
-type regionPrice struct {
-	instance map[string]instancePrice
-}
+// regionPrice spot prices of instance types in region
+type regionPrice map[string]float64
+
+// osPrice spot prices of operating system by region
+type osPrice map[string]regionPrice
+
 type spotPriceData struct {
-	region map[string]regionPrice
+	os       map[string]osPrice
+	embedded bool
+	checksum string
+	loadedAt time.Time
 }
 
-func pricingLazyLoad(url string, timeout time.Duration, fallbackData string, embedded bool) (*rawPriceData, error) {
+func pricingLazyLoad(ctx context.Context, url string, timeout time.Duration, fallbackData string, embedded bool) (*rawPriceData, error) {
 	var (
 		result     rawPriceData
 		bodyBytes  []byte
 		bodyString string
 		client     *http.Client
+		req        *http.Request
 		resp       *http.Response
 		err        error
 	)
@@ -85,9 +103,14 @@ func pricingLazyLoad(url string, timeout time.Duration, fallbackData string, emb
 		goto fallback
 	}
 	// try to load new data
-	client = &http.Client{Timeout: timeout}
+	client = newHTTPClient(timeout)
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		goto fallback
+	}
 
-	resp, err = client.Get(url)
+	resp, err = client.Do(req)
 	if err != nil {
 		goto fallback
 	}
@@ -97,6 +120,8 @@ func pricingLazyLoad(url string, timeout time.Duration, fallbackData string, emb
 	}()
 
 	if resp.StatusCode != http.StatusOK {
+		err = errors.Errorf("unexpected response status: %s", resp.Status)
+
 		goto fallback
 	}
 
@@ -106,6 +131,11 @@ func pricingLazyLoad(url string, timeout time.Duration, fallbackData string, emb
 		goto fallback
 	}
 
+	// reject data not matching expected checksum
+	if err = verifyChecksum(url, bodyBytes); err != nil {
+		goto fallback
+	}
+
 	bodyString = strings.TrimPrefix(string(bodyBytes), responsePrefix)
 	bodyString = strings.TrimSuffix(bodyString, responseSuffix)
 
@@ -114,9 +144,14 @@ func pricingLazyLoad(url string, timeout time.Duration, fallbackData string, emb
 		goto fallback
 	}
 
+	result.checksum = sha256sum(bodyBytes)
+
 	goto process
 
 fallback: // fallback to embedded load
+	if strict && !embedded {
+		return nil, errors.Wrapf(err, "failed to load %s (strict mode)", url)
+	}
 
 	if err = json.Unmarshal([]byte(fallbackData), &result); err != nil {
 		return nil, errors.Wrapf(err, "failed to parse embedded spot price data")
@@ -124,6 +159,7 @@ fallback: // fallback to embedded load
 
 	// set embedded loaded flag true
 	result.Embedded = true
+	result.checksum = sha256sum([]byte(fallbackData))
 
 process: // process loaded result
 	// replace non-standard Spot pricing region codes with AWS region codes
@@ -136,71 +172,102 @@ process: // process loaded result
 	return &result, nil
 }
 
-func convertRawData(raw *rawPriceData) *spotPriceData {
+// convertRawData index raw pricing data by OS, region and instance type; unparseable prices (e.g. "N/A") are zero,
+// or an error in strict mode
+func convertRawData(raw *rawPriceData) (*spotPriceData, error) {
 	// fill priceData from rawPriceData
 	var pricing spotPriceData
-	pricing.region = make(map[string]regionPrice)
+	pricing.os = make(map[string]osPrice)
+	pricing.embedded = raw.Embedded
+	pricing.checksum = raw.checksum
 
 	for _, region := range raw.Config.Regions {
-		var rp regionPrice
-		rp.instance = make(map[string]instancePrice)
-
 		for _, it := range region.InstanceTypes {
 			for _, size := range it.Sizes {
-				var ip instancePrice
-
-				for _, os := range size.ValueColumns {
-					price, err := strconv.ParseFloat(os.Prices.USD, 64)
+				for _, column := range size.ValueColumns {
+					price, err := strconv.ParseFloat(column.Prices.USD, 64)
 					if err != nil {
+						if strict {
+							return nil, errors.Errorf("invalid %s spot price %q of %s in %s (strict mode)",
+								column.Name, column.Prices.USD, size.Size, region.Region)
+						}
+
 						price = 0
 					}
 
-					if os.Name == "mswin" {
-						ip.windows = price
-					} else {
-						ip.linux = price
+					os, ok := priceColumnOS[column.Name]
+					if !ok {
+						os = strings.ToLower(column.Name)
 					}
-				}
 
-				rp.instance[size.Size] = ip
+					if pricing.os[os] == nil {
+						pricing.os[os] = make(osPrice)
+					}
+
+					if pricing.os[os][region.Region] == nil {
+						pricing.os[os][region.Region] = make(regionPrice)
+					}
+
+					pricing.os[os][region.Region][size.Size] = price
+				}
 			}
 		}
-
-		pricing.region[region.Region] = rp
 	}
 
-	return &pricing
+	return &pricing, nil
 }
 
-func getSpotInstancePrice(instance, region, os string, embedded bool) (float64, error) {
-	var (
-		err  error
-		data *rawPriceData
-	)
-
+func getSpotPriceData(ctx context.Context, embedded bool) (*spotPriceData, error) {
 	loadPriceOnce.Do(func() {
-		const timeout = 10
-		data, err = pricingLazyLoad(spotPriceJsURL, timeout*time.Second, embeddedPriceData, embedded)
-		spotPrice = convertRawData(data)
+		var data *rawPriceData
+
+		data, spotPriceErr = pricingLazyLoad(ctx, spotPriceJsURL, fetchTimeout, embeddedPriceData, embedded)
+		if spotPriceErr != nil {
+			return
+		}
+
+		if spotPrice, spotPriceErr = convertRawData(data); spotPriceErr == nil {
+			spotPrice.loadedAt = time.Now()
+		}
 	})
 
+	if spotPriceErr != nil {
+		return nil, errors.Wrap(spotPriceErr, "failed to load spot instance pricing")
+	}
+
+	return spotPrice, nil
+}
+
+func getSpotInstancePrice(ctx context.Context, instance, region, os string, embedded bool) (float64, error) {
+	spotPrice, err := getSpotPriceData(ctx, embedded)
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to load spot instance pricing")
+		return 0, err
 	}
 
-	rp, ok := spotPrice.region[region]
+	op, ok := spotPrice.os[strings.ToLower(os)]
 	if !ok {
-		return 0, errors.Errorf("no pricind fata for region: %v", region)
+		return 0, errors.Errorf("no pricing data for OS: %v", os)
 	}
 
-	price, ok := rp.instance[instance]
+	rp, ok := op[region]
 	if !ok {
-		return 0, errors.Errorf("no pricind fata for instance: %v", instance)
+		return 0, errors.Errorf("no pricing data for region: %v", region)
 	}
 
-	if os == "windows" {
-		return price.windows, nil
+	price, ok := rp[instance]
+	if !ok {
+		return 0, errors.Errorf("no pricing data for instance: %v", instance)
 	}
 
-	return price.linux, nil
+	return price, nil
+}
+
+// ParseOS validate instance operating system name, case insensitive
+func ParseOS(name string) (string, error) {
+	switch os := strings.ToLower(name); os {
+	case OSLinux, OSWindows:
+		return os, nil
+	default:
+		return "", errors.Errorf("invalid instance OS %q, must be %s/%s", name, OSWindows, OSLinux)
+	}
 }
@@ -1,6 +1,7 @@
 package spot
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
 	"testing"
@@ -63,7 +64,7 @@ func Test_pricingLazyLoad(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := pricingLazyLoad(tt.args.url, tt.args.timeout, tt.args.fallbackData, tt.args.embedded)
+			got, err := pricingLazyLoad(context.Background(), tt.args.url, tt.args.timeout, tt.args.fallbackData, tt.args.embedded)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("pricingLazyLoad() error = %v, wantErr %v", err, tt.wantErr)
 				return //nolint:nlreturn
@@ -112,14 +113,44 @@ func Test_convertRawData(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			var result rawPriceData
 			_ = json.Unmarshal([]byte(tt.args.priceData), &result)
-			got := convertRawData(&result)
-			if len(got.region) != tt.want.regionsLen {
-				t.Errorf("convertRawData() regions = %v, want %v", len(got.region), tt.want.regionsLen)
+			got, err := convertRawData(&result)
+			if err != nil {
+				t.Fatalf("convertRawData() error = %v", err)
+			}
+			if len(got.os[OSLinux]) != tt.want.regionsLen {
+				t.Errorf("convertRawData() regions = %v, want %v", len(got.os[OSLinux]), tt.want.regionsLen)
 			}
 		})
 	}
 }
 
+func Test_convertRawData_unparseablePrice(t *testing.T) {
+	const priceData = `{"config": {"regions": [{"region": "us-east-1", "instanceTypes": [{"type": "generalCurrentGen",
+		"sizes": [{"size": "m5.large", "valueColumns": [{"name": "linux", "prices": {"USD": "N/A*"}}]}]}]}]}}`
+
+	var result rawPriceData
+	if err := json.Unmarshal([]byte(priceData), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := convertRawData(&result)
+	if err != nil {
+		t.Fatalf("convertRawData() error = %v", err)
+	}
+
+	if price := got.os[OSLinux]["us-east-1"]["m5.large"]; price != 0 {
+		t.Errorf("convertRawData() price = %v, want 0", price)
+	}
+
+	defer SetStrict(false)
+
+	SetStrict(true)
+
+	if _, err = convertRawData(&result); err == nil {
+		t.Error("convertRawData() strict mode: want error on unparseable price")
+	}
+}
+
 func Test_getSpotInstancePrice(t *testing.T) {
 	type args struct {
 		instance string
@@ -141,6 +172,25 @@ func Test_getSpotInstancePrice(t *testing.T) {
 				embedded: true,
 			},
 		},
+		{
+			name: "get price of us-east-1 t2.micro windows instance",
+			args: args{
+				region:   "us-east-1",
+				os:       "Windows",
+				instance: "t2.micro",
+				embedded: true,
+			},
+		},
+		{
+			name: "fail: get price for unknown OS",
+			args: args{
+				region:   "us-east-1",
+				os:       "reactos",
+				instance: "t2.micro",
+				embedded: true,
+			},
+			wantErr: true,
+		},
 		{
 			name: "fail: get price for non-existing region",
 			args: args{
@@ -165,7 +215,7 @@ func Test_getSpotInstancePrice(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getSpotInstancePrice(tt.args.instance, tt.args.region, tt.args.os, tt.args.embedded)
+			got, err := getSpotInstancePrice(context.Background(), tt.args.instance, tt.args.region, tt.args.os, tt.args.embedded)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getSpotInstancePrice() error = %v, wantErr %v", err, tt.wantErr)
 				return //nolint:nlreturn
@@ -176,3 +226,46 @@ func Test_getSpotInstancePrice(t *testing.T) {
 		})
 	}
 }
+
+func Test_getSpotInstancePrice_perOS(t *testing.T) {
+	ctx := context.Background()
+
+	linux, err := getSpotInstancePrice(ctx, "t2.micro", "us-east-1", OSLinux, true)
+	if err != nil {
+		t.Fatalf("getSpotInstancePrice() linux error = %v", err)
+	}
+
+	windows, err := getSpotInstancePrice(ctx, "t2.micro", "us-east-1", OSWindows, true)
+	if err != nil {
+		t.Fatalf("getSpotInstancePrice() windows error = %v", err)
+	}
+
+	if linux == windows {
+		t.Errorf("getSpotInstancePrice() windows price = linux price = %v", linux)
+	}
+}
+
+func TestParseOS(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "linux", want: OSLinux},
+		{name: "Windows", want: OSWindows},
+		{name: "LINUX", want: OSLinux},
+		{name: "reactos", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOS(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
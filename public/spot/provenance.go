@@ -0,0 +1,39 @@
+package spot
+
+import "time"
+
+// FeedProvenance source of the data feed used to produce results
+type FeedProvenance struct {
+	Name     string    `json:"name"`
+	URL      string    `json:"url"`
+	Embedded bool      `json:"embedded"`  // loaded from the copy embedded during the build
+	LoadedAt time.Time `json:"loaded_at"` //nolint:tagliatelle
+	SHA256   string    `json:"sha256"`    // checksum of the loaded data
+}
+
+// Provenance sources of loaded data feeds; call after a query, feeds are loaded lazily on first use
+func Provenance() []FeedProvenance {
+	var result []FeedProvenance
+
+	if data != nil {
+		result = append(result, FeedProvenance{
+			Name:     "spot advisor",
+			URL:      spotAdvisorJSONURL,
+			Embedded: data.Embedded,
+			LoadedAt: data.loadedAt,
+			SHA256:   data.checksum,
+		})
+	}
+
+	if spotPrice != nil {
+		result = append(result, FeedProvenance{
+			Name:     "spot pricing",
+			URL:      spotPriceJsURL,
+			Embedded: spotPrice.embedded,
+			LoadedAt: spotPrice.loadedAt,
+			SHA256:   spotPrice.checksum,
+		})
+	}
+
+	return result
+}
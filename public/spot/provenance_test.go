@@ -0,0 +1,23 @@
+package spot
+
+import (
+	"context"
+	"testing"
+)
+
+func TestProvenance(t *testing.T) {
+	if _, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5\\.large$", "linux", 0, 0, 0, SortByRange, false); err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	got := Provenance()
+	if len(got) != 2 { //nolint:gomnd
+		t.Fatalf("Provenance() = %v feeds, want 2", len(got))
+	}
+
+	for _, feed := range got {
+		if feed.URL == "" || feed.SHA256 == "" || feed.LoadedAt.IsZero() {
+			t.Errorf("Provenance() incomplete feed provenance: %+v", feed)
+		}
+	}
+}
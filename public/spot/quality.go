@@ -0,0 +1,59 @@
+package spot
+
+import "context"
+
+// data quality flags of advice
+const (
+	// no spot price of instance type in region: Price is 0
+	FlagPriceMissing = "price_missing"
+	// region is not covered by spot pricing data feed (implies price_missing)
+	FlagAdvisorOnly = "advisor_only"
+	// no vCPU and memory data of instance type: Info is empty
+	FlagInfoMissing = "info_missing"
+	// based on embedded copy of data feeds, possibly outdated
+	FlagEmbeddedData = "embedded_data"
+)
+
+// HasFlag advice has data quality flag
+func (a *Advice) HasFlag(flag string) bool {
+	return hasTag(a.Flags, flag)
+}
+
+// WithoutFlags drop advices with any of the data quality flags (e.g. price_missing)
+func WithoutFlags(flags ...string) Option {
+	return WithFilter(func(a Advice) bool {
+		for _, flag := range flags {
+			if a.HasFlag(flag) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// qualityFlags data quality flags of advice with loaded data feeds
+func qualityFlags(ctx context.Context, region, os string, infoFound, priceFound bool) []string {
+	var flags []string
+
+	if !priceFound {
+		flags = append(flags, FlagPriceMissing)
+	}
+
+	pricing, err := getSpotPriceData(ctx, false)
+	if err == nil {
+		if _, ok := pricing.os[os][region]; !ok {
+			flags = append(flags, FlagAdvisorOnly)
+		}
+	}
+
+	if !infoFound {
+		flags = append(flags, FlagInfoMissing)
+	}
+
+	if (data != nil && data.Embedded) || (err == nil && pricing.embedded) {
+		flags = append(flags, FlagEmbeddedData)
+	}
+
+	return flags
+}
@@ -0,0 +1,60 @@
+package spot
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAdvice_HasFlag(t *testing.T) {
+	a := Advice{Flags: []string{FlagPriceMissing, FlagAdvisorOnly}}
+
+	if !a.HasFlag(FlagAdvisorOnly) {
+		t.Errorf("HasFlag(%v) = false, want true", FlagAdvisorOnly)
+	}
+
+	if a.HasFlag(FlagEmbeddedData) {
+		t.Errorf("HasFlag(%v) = true, want false", FlagEmbeddedData)
+	}
+}
+
+func TestGetSpotSavings_flags(t *testing.T) {
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5\\.", "linux", 0, 0, 0, SortByInstance, false)
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	pricing, err := getSpotPriceData(context.Background(), false)
+	if err != nil {
+		t.Fatalf("getSpotPriceData() error = %v", err)
+	}
+
+	embedded := data.Embedded || pricing.embedded
+
+	for _, a := range got {
+		if a.HasFlag(FlagEmbeddedData) != embedded {
+			t.Errorf("%v: HasFlag(%v) = %v, want %v", a.Instance, FlagEmbeddedData, !embedded, embedded)
+		}
+
+		if a.HasFlag(FlagPriceMissing) && a.Price != 0 {
+			t.Errorf("%v: flagged %v with price %v", a.Instance, FlagPriceMissing, a.Price)
+		}
+
+		if a.HasFlag(FlagInfoMissing) != (a.Info.Cores == 0) {
+			t.Errorf("%v: HasFlag(%v) = %v with %d vCPU", a.Instance, FlagInfoMissing, a.HasFlag(FlagInfoMissing), a.Info.Cores)
+		}
+	}
+}
+
+func TestWithoutFlags(t *testing.T) {
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^m5\\.", "linux", 0, 0, 0, SortByInstance, false,
+		WithoutFlags(FlagEmbeddedData, FlagPriceMissing))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	for _, a := range got {
+		if a.HasFlag(FlagEmbeddedData) || a.HasFlag(FlagPriceMissing) {
+			t.Errorf("GetSpotSavings() = %v with flags %v, want excluded", a.Instance, a.Flags)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package spot
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RecordEnv directory to record HTTP responses (data feeds) into
+	RecordEnv = "SPOTINFO_RECORD"
+	// ReplayEnv directory to replay recorded HTTP responses from, no network calls are made
+	ReplayEnv = "SPOTINFO_REPLAY"
+)
+
+// replace URL separators to get a flat fixture file name
+var fixtureNameReplacer = strings.NewReplacer("/", "_", ":", "_", "?", "_", "&", "_", "=", "_")
+
+// recordTransport saves successful responses into fixture files
+type recordTransport struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// replayTransport serves responses from fixture files
+type replayTransport struct {
+	dir string
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: fixtureTransport(http.DefaultTransport)}
+}
+
+// fixtureTransport decorate transport with record/replay, based on environment
+func fixtureTransport(next http.RoundTripper) http.RoundTripper {
+	if dir := os.Getenv(ReplayEnv); dir != "" {
+		return &replayTransport{dir: dir}
+	}
+
+	if dir := os.Getenv(RecordEnv); dir != "" {
+		return &recordTransport{dir: dir, next: next}
+	}
+
+	return next
+}
+
+func fixturePath(dir string, req *http.Request) string {
+	return filepath.Join(dir, fixtureNameReplacer.Replace(req.URL.Host+req.URL.RequestURI()))
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err //nolint:wrapcheck
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	if err = os.MkdirAll(t.dir, 0o755); err != nil { //nolint:gomnd
+		return nil, errors.Wrap(err, "failed to create fixtures directory")
+	}
+
+	if err = ioutil.WriteFile(fixturePath(t.dir, req), body, 0o644); err != nil { //nolint:gosec,gomnd
+		return nil, errors.Wrap(err, "failed to record fixture")
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadFile(fixturePath(t.dir, req))
+	if err != nil {
+		return nil, errors.Wrapf(err, "no recorded fixture for %s", req.URL)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
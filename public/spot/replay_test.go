@@ -0,0 +1,67 @@
+package spot
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_recordReplayTransport(t *testing.T) {
+	const body = `{"ranges":[]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data.json" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	// record
+	recorder := &http.Client{Transport: &recordTransport{dir: dir, next: http.DefaultTransport}}
+
+	resp, err := recorder.Get(server.URL + "/data.json")
+	if err != nil {
+		t.Fatalf("record: unexpected error = %v", err)
+	}
+
+	got, _ := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if string(got) != body {
+		t.Errorf("record: body = %v, want %v", string(got), body)
+	}
+
+	// not found responses are not recorded
+	resp, err = recorder.Get(server.URL + "/missing.json")
+	if err != nil {
+		t.Fatalf("record: unexpected error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// replay without server
+	server.Close()
+
+	player := &http.Client{Transport: &replayTransport{dir: dir}}
+
+	resp, err = player.Get(server.URL + "/data.json")
+	if err != nil {
+		t.Fatalf("replay: unexpected error = %v", err)
+	}
+
+	got, _ = ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if string(got) != body {
+		t.Errorf("replay: body = %v, want %v", string(got), body)
+	}
+
+	if _, err = player.Get(server.URL + "/missing.json"); err == nil { //nolint:bodyclose
+		t.Error("replay: expected error for missing fixture")
+	}
+}
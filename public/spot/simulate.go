@@ -0,0 +1,95 @@
+package spot
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FleetMember instance type and number of instances in a fleet mix
+type FleetMember struct {
+	Instance string `json:"instance"`
+	Count    int    `json:"count"`
+}
+
+// FleetSimulation estimated fleet cost including interruption overhead
+type FleetSimulation struct {
+	Region  string        `json:"region"`
+	Mix     []FleetMember `json:"mix"`
+	Restart time.Duration `json:"-"`
+	// spot price of the fleet, USD per hour
+	SpotCost float64 `json:"spot_cost"` //nolint:tagliatelle
+	// cost of work lost and redone after interruptions, USD per hour
+	Overhead float64 `json:"overhead"`
+	// spot cost plus interruption overhead, USD per hour
+	EffectiveCost float64 `json:"effective_cost"` //nolint:tagliatelle
+	// expected number of interrupted instances per month
+	Interruptions float64 `json:"interruptions"`
+}
+
+// interruptionRate expected interruptions per instance hour: interruption range midpoint is a monthly percentage
+func interruptionRate(a *Advice) float64 {
+	return a.Range.Mid() / 100 / HoursPerMonth //nolint:gomnd
+}
+
+// EffectiveCost hourly spot price plus the expected cost of redoing restart worth of work after each interruption
+func (a *Advice) EffectiveCost(restart time.Duration) float64 {
+	return a.Price * (1 + interruptionRate(a)*restart.Hours())
+}
+
+// InterruptionProbability probability of at least one interruption of a spot instance running for duration
+func (a *Advice) InterruptionProbability(duration time.Duration) float64 {
+	return 1 - math.Exp(-interruptionRate(a)*duration.Hours())
+}
+
+// LostHours expected compute hours lost per instance per month in interruption range, when work is checkpointed
+// every checkpoint interval (half of it is lost on average) and restart takes restart time
+func LostHours(r Range, checkpoint, restart time.Duration) float64 {
+	return r.Mid() / 100 * (checkpoint.Hours()/2 + restart.Hours()) //nolint:gomnd
+}
+
+// LostCost expected cost of lost compute per instance per month, USD
+func (a *Advice) LostCost(checkpoint, restart time.Duration) float64 {
+	return a.Price * LostHours(a.Range, checkpoint, restart)
+}
+
+// SimulateFleet estimate cost of a fleet mix in region, where each interruption loses restart worth of work
+func SimulateFleet(ctx context.Context, region, instanceOS string, mix []FleetMember, restart time.Duration) (*FleetSimulation, error) {
+	if len(mix) == 0 {
+		return nil, errors.New("empty fleet mix")
+	}
+
+	if restart < 0 {
+		return nil, errors.Errorf("negative restart cost: %v", restart)
+	}
+
+	result := FleetSimulation{Region: region, Mix: mix, Restart: restart}
+
+	for _, m := range mix {
+		if m.Count <= 0 {
+			return nil, errors.Errorf("invalid number of %s instances: %d", m.Instance, m.Count)
+		}
+
+		advices, err := GetSpotSavingsContext(ctx, []string{region}, "^"+regexp.QuoteMeta(m.Instance)+"$", instanceOS, 0, 0, 0, SortByRange, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get spot advice for %s", m.Instance)
+		}
+
+		if len(advices) == 0 {
+			return nil, errors.Errorf("no spot advice for %s in %s", m.Instance, region)
+		}
+
+		a := &advices[0]
+		count := float64(m.Count)
+		result.SpotCost += count * a.Price
+		result.EffectiveCost += count * a.EffectiveCost(restart)
+		result.Interruptions += count * interruptionRate(a) * HoursPerMonth
+	}
+
+	result.Overhead = result.EffectiveCost - result.SpotCost
+
+	return &result, nil
+}
@@ -0,0 +1,111 @@
+package spot
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAdvice_EffectiveCost(t *testing.T) {
+	// >20% range midpoint is 61.5% interruptions per month
+	advice := Advice{Price: 0.1, Range: Range{Label: ">20%", Min: 23, Max: 100}}
+
+	tests := []struct {
+		name    string
+		restart time.Duration
+		want    float64
+	}{
+		{name: "no restart cost", restart: 0, want: 0.1},
+		{name: "one hour restart cost", restart: time.Hour, want: 0.1 * (1 + 0.615/HoursPerMonth)},
+		{name: "ten hours restart cost", restart: 10 * time.Hour, want: 0.1 * (1 + 6.15/HoursPerMonth)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := advice.EffectiveCost(tt.restart); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Advice.EffectiveCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdvice_InterruptionProbability(t *testing.T) {
+	advice := Advice{Price: 0.1, Range: Range{Label: ">20%", Min: 23, Max: 100}}
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		want     float64
+	}{
+		{name: "no duration", duration: 0, want: 0},
+		{name: "6 hours", duration: 6 * time.Hour, want: 1 - math.Exp(-0.615*6/730)},
+		{name: "month", duration: HoursPerMonth * time.Hour, want: 1 - math.Exp(-0.615)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := advice.InterruptionProbability(tt.duration); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Advice.InterruptionProbability() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLostHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		rng        Range
+		checkpoint time.Duration
+		restart    time.Duration
+		want       float64
+	}{
+		{name: "<5% range", rng: Range{Label: "<5%", Min: 0, Max: 5}, checkpoint: 2 * time.Hour, restart: 0, want: 0.025},
+		{name: ">20% range", rng: Range{Label: ">20%", Min: 23, Max: 100}, checkpoint: time.Hour, restart: 30 * time.Minute, want: 0.615},
+		{name: "no lost work", rng: Range{Label: ">20%", Min: 23, Max: 100}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LostHours(tt.rng, tt.checkpoint, tt.restart); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("LostHours() = %v, want %v", got, tt.want)
+			}
+
+			advice := Advice{Price: 0.2, Range: tt.rng}
+			if got := advice.LostCost(tt.checkpoint, tt.restart); math.Abs(got-0.2*tt.want) > 1e-12 {
+				t.Errorf("Advice.LostCost() = %v, want %v", got, 0.2*tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulateFleet(t *testing.T) {
+	tests := []struct {
+		name    string
+		mix     []FleetMember
+		restart time.Duration
+		wantErr bool
+	}{
+		{name: "single instance type", mix: []FleetMember{{Instance: "m5.large", Count: 4}}, restart: 30 * time.Minute},
+		{name: "mixed instance types", mix: []FleetMember{{Instance: "m5.large", Count: 2}, {Instance: "m5.xlarge", Count: 1}}, restart: time.Hour},
+		{name: "fail on empty mix", wantErr: true},
+		{name: "fail on zero count", mix: []FleetMember{{Instance: "m5.large"}}, wantErr: true},
+		{name: "fail on negative restart cost", mix: []FleetMember{{Instance: "m5.large", Count: 1}}, restart: -time.Minute, wantErr: true},
+		{name: "fail on unknown instance type", mix: []FleetMember{{Instance: "x9.huge", Count: 1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SimulateFleet(context.Background(), "us-east-1", "linux", tt.mix, tt.restart)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SimulateFleet() error = %v, wantErr %v", err, tt.wantErr)
+				return //nolint:nlreturn
+			}
+			if got == nil {
+				return
+			}
+			if got.SpotCost <= 0 || got.EffectiveCost < got.SpotCost || got.Overhead < 0 {
+				t.Errorf("SimulateFleet() inconsistent costs: %+v", got)
+			}
+			if got.Interruptions <= 0 {
+				t.Errorf("SimulateFleet() interruptions = %v, want > 0", got.Interruptions)
+			}
+		})
+	}
+}
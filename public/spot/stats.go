@@ -0,0 +1,65 @@
+package spot
+
+import (
+	"sort"
+)
+
+// BandShare share of instances in interruption range
+type BandShare struct {
+	Range Range   `json:"range"`
+	Share float64 `json:"share"` // 0-1
+}
+
+// RegionStats savings and interruption distribution of region instance types
+type RegionStats struct {
+	Region        string      `json:"region"`
+	Instances     int         `json:"instances"`
+	MeanSavings   float64     `json:"mean_savings"`   //nolint:tagliatelle
+	MedianSavings float64     `json:"median_savings"` //nolint:tagliatelle
+	Bands         []BandShare `json:"bands"`          // ordered from least to most frequent interruptions
+}
+
+// Stats summarize advices per region (sorted by region)
+func Stats(advices []Advice) []RegionStats {
+	byRegion := make(map[string][]*Advice)
+	for i := range advices {
+		byRegion[advices[i].Region] = append(byRegion[advices[i].Region], &advices[i])
+	}
+
+	result := make([]RegionStats, 0, len(byRegion))
+
+	for region, list := range byRegion {
+		stats := RegionStats{Region: region, Instances: len(list)}
+		savings := make([]int, 0, len(list))
+		bands := make(map[Range]int)
+
+		total := 0
+		for _, a := range list {
+			total += a.Savings
+			savings = append(savings, a.Savings)
+			bands[a.Range]++
+		}
+
+		stats.MeanSavings = float64(total) / float64(len(list))
+
+		sort.Ints(savings)
+
+		if mid := len(savings) / 2; len(savings)%2 == 1 { //nolint:gomnd
+			stats.MedianSavings = float64(savings[mid])
+		} else {
+			stats.MedianSavings = float64(savings[mid-1]+savings[mid]) / 2 //nolint:gomnd
+		}
+
+		for r, count := range bands {
+			stats.Bands = append(stats.Bands, BandShare{Range: r, Share: float64(count) / float64(len(list))})
+		}
+
+		sort.Slice(stats.Bands, func(i, j int) bool { return stats.Bands[i].Range.Max < stats.Bands[j].Range.Max })
+
+		result = append(result, stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Region < result[j].Region })
+
+	return result
+}
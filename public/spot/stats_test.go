@@ -0,0 +1,35 @@
+package spot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	low := Range{Label: "<5%", Min: 0, Max: 5}
+	high := Range{Label: ">20%", Min: 23, Max: 100}
+
+	advices := []Advice{
+		{Region: "us-east-1", Instance: "m5.large", Range: high, Savings: 40},
+		{Region: "eu-west-1", Instance: "m5.large", Range: low, Savings: 70},
+		{Region: "us-east-1", Instance: "m5.xlarge", Range: low, Savings: 60},
+		{Region: "us-east-1", Instance: "m5.2xlarge", Range: low, Savings: 80},
+		{Region: "us-east-1", Instance: "m5.4xlarge", Range: high, Savings: 50},
+	}
+
+	want := []RegionStats{
+		{Region: "eu-west-1", Instances: 1, MeanSavings: 70, MedianSavings: 70, Bands: []BandShare{{Range: low, Share: 1}}},
+		{
+			Region: "us-east-1", Instances: 4, MeanSavings: 57.5, MedianSavings: 55,
+			Bands: []BandShare{{Range: low, Share: 0.5}, {Range: high, Share: 0.5}},
+		},
+	}
+
+	if got := Stats(advices); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	if got := Stats(nil); len(got) != 0 {
+		t.Errorf("Stats() = %+v, want empty", got)
+	}
+}
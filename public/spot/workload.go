@@ -0,0 +1,130 @@
+package spot
+
+import "strings"
+
+// workload tags of instance types
+const (
+	// TagEFA instance type supports Elastic Fabric Adapter (HPC and ML workloads)
+	TagEFA = "efa"
+	// TagSAPCertified instance family is certified for SAP HANA
+	TagSAPCertified = "sap-certified"
+	// TagHibernate spot instance can hibernate (or stop) instead of terminate on interruption
+	TagHibernate = "hibernate"
+	// TagNeuron AWS Inferentia/Trainium accelerator instance type: models must be compiled with AWS Neuron SDK
+	TagNeuron = "neuron"
+)
+
+// maximum memory (GiB) of instance types supporting hibernation
+const maxHibernateRAM = 150
+
+// EFA capable instance types
+var efaInstances = map[string]bool{
+	"c5n.18xlarge": true, "c5n.9xlarge": true, "c5n.metal": true,
+	"c6gn.16xlarge": true, "c6i.32xlarge": true, "c6i.metal": true, "c6id.32xlarge": true, "c6a.48xlarge": true,
+	"c7g.16xlarge": true, "c7gn.16xlarge": true, "c7i.48xlarge": true,
+	"g4dn.8xlarge": true, "g4dn.12xlarge": true, "g4dn.16xlarge": true, "g4dn.metal": true,
+	"g5.8xlarge": true, "g5.12xlarge": true, "g5.16xlarge": true, "g5.24xlarge": true, "g5.48xlarge": true,
+	"hpc6a.48xlarge": true, "hpc6id.32xlarge": true, "hpc7g.16xlarge": true,
+	"i3en.24xlarge": true, "i3en.metal": true, "i4i.32xlarge": true,
+	"inf1.24xlarge": true, "trn1.32xlarge": true,
+	"m5dn.24xlarge": true, "m5n.24xlarge": true, "m5zn.12xlarge": true, "m5zn.metal": true,
+	"m6i.32xlarge": true, "m6i.metal": true, "m6id.32xlarge": true, "m6a.48xlarge": true, "m7g.16xlarge": true,
+	"p3dn.24xlarge": true, "p4d.24xlarge": true, "p4de.24xlarge": true, "p5.48xlarge": true,
+	"r5dn.24xlarge": true, "r5n.24xlarge": true, "r6i.32xlarge": true, "r6i.metal": true, "r6id.32xlarge": true, "r7g.16xlarge": true,
+	"x2idn.32xlarge": true, "x2iedn.32xlarge": true,
+}
+
+// SAP HANA certified instance families (family level: not every size is certified, see SAP Note 1656099)
+var sapCertifiedFamilies = map[string]bool{
+	"r3": true, "r4": true, "r5": true, "r5b": true, "r6i": true, "r7i": true,
+	"x1": true, "x1e": true, "x2idn": true, "x2iedn": true, "x2iezn": true,
+}
+
+// instance families supporting hibernation (spot interruption behavior hibernate)
+var hibernateFamilies = map[string]bool{
+	"c3": true, "c4": true, "c5": true, "c5d": true, "c6a": true, "c6g": true, "c6gd": true, "c6i": true, "c6id": true,
+	"c7a": true, "c7g": true, "c7i": true, "i3": true,
+	"m3": true, "m4": true, "m5": true, "m5a": true, "m5ad": true, "m5d": true, "m6a": true, "m6g": true, "m6gd": true,
+	"m6i": true, "m6id": true, "m7a": true, "m7g": true, "m7i": true, "m7i-flex": true,
+	"r3": true, "r4": true, "r5": true, "r5a": true, "r5ad": true, "r5d": true, "r6a": true, "r6g": true, "r6gd": true,
+	"r6i": true, "r6id": true, "r7a": true, "r7g": true, "r7i": true, "r7iz": true,
+	"t2": true, "t3": true, "t3a": true, "t4g": true,
+}
+
+// AWS Inferentia/Trainium accelerator families
+var neuronFamilies = map[string]bool{
+	"inf1": true, "inf2": true, "trn1": true, "trn1n": true,
+}
+
+// Inferentia/Trainium alternatives of GPU instance families: inference GPUs to Inferentia, training GPUs to Trainium
+var acceleratorAlternatives = map[string][]string{
+	"g4dn": {"inf2"}, "g4ad": {"inf2"}, "g5": {"inf2"}, "g5g": {"inf2"}, "g6": {"inf2"}, "g6e": {"inf2"},
+	"p3": {"trn1"}, "p3dn": {"trn1"}, "p4d": {"trn1", "trn1n"}, "p4de": {"trn1", "trn1n"}, "p5": {"trn1n"},
+}
+
+// AcceleratorAlternatives get AWS Inferentia/Trainium instance families that can replace GPU instance type (nil for
+// non GPU instance types); see TagNeuron caveat
+func AcceleratorAlternatives(instance string) []string {
+	return acceleratorAlternatives[instanceFamily(instance)]
+}
+
+// InstanceTags get workload tags of instance type; hibernation memory limit is checked when spot advisor data is loaded
+func InstanceTags(instance string) []string {
+	var ram float32
+	if data != nil {
+		ram = data.InstanceTypes[instance].RAM
+	}
+
+	return instanceTags(instance, ram)
+}
+
+// hibernateCapable instance type supports hibernation: supported family, not bare metal, less than 150 GiB memory
+func hibernateCapable(instance string, ram float32) bool {
+	return hibernateFamilies[instanceFamily(instance)] && !strings.HasSuffix(instance, ".metal") && ram < maxHibernateRAM
+}
+
+func instanceTags(instance string, ram float32) []string {
+	var tags []string
+
+	if efaInstances[instance] {
+		tags = append(tags, TagEFA)
+	}
+
+	// high memory u-*tb1 instances are SAP HANA certified
+	if family := instanceFamily(instance); sapCertifiedFamilies[family] || strings.HasPrefix(family, "u-") {
+		tags = append(tags, TagSAPCertified)
+	}
+
+	if neuronFamilies[instanceFamily(instance)] {
+		tags = append(tags, TagNeuron)
+	}
+
+	if hibernateCapable(instance, ram) {
+		tags = append(tags, TagHibernate)
+	}
+
+	return tags
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// missingTags required tags not in tags
+func missingTags(tags, required []string) []string {
+	var missing []string
+
+	for _, r := range required {
+		if !hasTag(tags, r) {
+			missing = append(missing, r)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,81 @@
+package spot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_instanceTags(t *testing.T) {
+	tests := []struct {
+		instance string
+		ram      float32
+		want     []string
+	}{
+		{instance: "c5n.18xlarge", ram: 192, want: []string{TagEFA}},
+		{instance: "r6i.32xlarge", ram: 1024, want: []string{TagEFA, TagSAPCertified}},
+		{instance: "r5.2xlarge", ram: 64, want: []string{TagSAPCertified, TagHibernate}},
+		{instance: "u-6tb1.metal", ram: 6144, want: []string{TagSAPCertified}},
+		{instance: "m5.large", ram: 8, want: []string{TagHibernate}},
+		{instance: "m5.metal", ram: 96, want: nil},
+		{instance: "c5.18xlarge", ram: 144, want: []string{TagHibernate}},
+		{instance: "m5.12xlarge", ram: 192, want: nil},
+		{instance: "g5.xlarge", ram: 16, want: nil},
+		{instance: "inf2.xlarge", ram: 16, want: []string{TagNeuron}},
+		{instance: "trn1.32xlarge", ram: 512, want: []string{TagEFA, TagNeuron}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instance, func(t *testing.T) {
+			if got := instanceTags(tt.instance, tt.ram); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("instanceTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcceleratorAlternatives(t *testing.T) {
+	tests := []struct {
+		instance string
+		want     []string
+	}{
+		{instance: "g5.xlarge", want: []string{"inf2"}},
+		{instance: "p4d.24xlarge", want: []string{"trn1", "trn1n"}},
+		{instance: "m5.large", want: nil},
+		{instance: "inf2.xlarge", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instance, func(t *testing.T) {
+			if got := AcceleratorAlternatives(tt.instance); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AcceleratorAlternatives() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSpotSavings_withTags(t *testing.T) {
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^(m5|r5)\\.", "linux", 0, 0, 0, SortByRange, false,
+		WithTags(TagSAPCertified))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	for _, advice := range got {
+		if !hasTag(advice.Tags, TagSAPCertified) {
+			t.Errorf("GetSpotSavings() instance %v without %s tag in results", advice.Instance, TagSAPCertified)
+		}
+	}
+}
+
+func TestGetSpotSavings_withHibernateTag(t *testing.T) {
+	got, err := GetSpotSavingsContext(context.Background(), []string{"us-east-1"}, "^(m5|g5)\\.", "linux", 0, 0, 0, SortByRange, false,
+		WithTags(TagHibernate))
+	if err != nil {
+		t.Fatalf("GetSpotSavings() error = %v", err)
+	}
+
+	for _, advice := range got {
+		if !hasTag(advice.Tags, TagHibernate) || advice.Info.RAM >= maxHibernateRAM || instanceFamily(advice.Instance) != "m5" {
+			t.Errorf("GetSpotSavings() instance %v (%vGiB) is not hibernation capable", advice.Instance, advice.Info.RAM)
+		}
+	}
+}